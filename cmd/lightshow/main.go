@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/shivanshkc/lightshow/pkg/camera"
@@ -38,6 +39,7 @@ var renderOptions = &renderer.Options{
 	MaxDiffusionDepth: 50,
 	SamplesPerPixel:   50,
 	MaxWorkers:        400,
+	Progress:          os.Stdout,
 	OutputFile:        "./dist/image.jpg",
 }
 
@@ -111,7 +113,9 @@ outer:
 			}
 
 			// If the shape is intersecting, we continue.
-			if sphere.Center.Sub(center).Mag() < sphere.Radius+radius {
+			// Squared values are compared to avoid a square root.
+			radiusSum := sphere.Radius + radius
+			if sphere.Center.DistanceSquared(center) < radiusSum*radiusSum {
 				continue outer
 			}
 		}
@@ -130,7 +134,7 @@ outer:
 		}
 
 		// Add to the world.
-		world.Shapes = append(world.Shapes, shapes.NewSphere(center, radius, mat))
+		world.Add(shapes.NewSphere(center, radius, mat))
 		i++
 	}
 }
@@ -2,4 +2,7 @@ module github.com/shivanshkc/lightshow
 
 go 1.20
 
-require github.com/alitto/pond v1.8.3
+require (
+	github.com/alitto/pond v1.8.3
+	golang.org/x/image v0.18.0
+)
@@ -1,6 +1,8 @@
 package camera
 
 import (
+	"errors"
+	"fmt"
 	"math"
 
 	"github.com/shivanshkc/lightshow/pkg/random"
@@ -19,6 +21,10 @@ type Camera struct {
 
 	// lensRadius allows depth of field effect.
 	lensRadius float64
+	// focusDistance is the distance of the plane of focus from the camera.
+	focusDistance float64
+	// shutterOpen and shutterClose bound the times of the cast rays.
+	shutterOpen, shutterClose float64
 }
 
 // Options to create a new camera.
@@ -39,6 +45,14 @@ type Options struct {
 	Aperture float64
 	// FocusDistance for the depth of field effect.
 	FocusDistance float64
+	// AutoFocus sets the FocusDistance to the distance between LookFrom and LookAt,
+	// so that the subject at LookAt is sharp. The FocusDistance field is ignored if it is true.
+	AutoFocus bool
+
+	// ShutterOpen and ShutterClose are the times between which the shutter is open.
+	// Every ray gets a random time in this interval, so the shapes that change over time
+	// look as they would in a photograph with that exposure. Both are zero by default.
+	ShutterOpen, ShutterClose float64
 }
 
 // New creates a new camera using the given options.
@@ -56,45 +70,176 @@ func New(opts *Options) *Camera {
 	viewportHeight := 2 * math.Tan(fovRadians/2)
 	viewportWidth := opts.AspectRatio * viewportHeight
 
+	focusDistance := opts.FocusDistance
+	if opts.AutoFocus {
+		focusDistance = opts.LookFrom.Sub(opts.LookAt).Mag()
+	}
+
 	// To understand the FocusDistance math, visit-
 	// https://raytracing.github.io/books/RayTracingInOneWeekend.html#defocusblur/athinlensapproximation
 	origin := opts.LookFrom
-	horizontal := cameraU.Mul(viewportWidth * opts.FocusDistance)
-	vertical := cameraV.Mul(viewportHeight * opts.FocusDistance)
+	horizontal := cameraU.Mul(viewportWidth * focusDistance)
+	vertical := cameraV.Mul(viewportHeight * focusDistance)
 	lowerLeftCorner := origin.
 		Sub(horizontal.Div(2)).
 		Sub(vertical.Div(2)).
-		Sub(cameraW.Mul(opts.FocusDistance))
+		Sub(cameraW.Mul(focusDistance))
 
 	return &Camera{
 		camU: cameraU, camV: cameraV, camW: cameraW,
 		origin: origin, horizontal: horizontal, vertical: vertical, lowerLeftCorner: lowerLeftCorner,
-		lensRadius: opts.Aperture / 2,
+		lensRadius: opts.Aperture / 2, focusDistance: focusDistance,
+		shutterOpen: opts.ShutterOpen, shutterClose: opts.ShutterClose,
 	}
 }
 
 // CastRay returns a Ray instance that originates at the camera's origin
 // and goes toward the given xy location on the viewport.
-func (c *Camera) CastRay(viewportX, viewportY float64) *utils.Ray {
+//
+// The given Generator is used to pick a point on the lens for the depth of field effect,
+// and a time between the shutter open and close times.
+func (c *Camera) CastRay(viewportX, viewportY float64, rng *random.Generator) *utils.Ray {
 	// TODO: Understand this math.
 	// Docs are present at-
 	// https://raytracing.github.io/books/RayTracingInOneWeekend.html#defocusblur/generatingsamplerays
-	rd := random.Vec3InUnitDisk().Mul(c.lensRadius)
+	rd := rng.Vec3InUnitDiskConcentric().Mul(c.lensRadius)
 	offset := c.camU.Mul(rd.X).Add(c.camV.Mul(rd.Y))
 
+	ray := c.castRayFromLens(viewportX, viewportY, offset)
+	if c.shutterClose > c.shutterOpen {
+		ray.Time = rng.FloatBetween(c.shutterOpen, c.shutterClose)
+	}
+
+	return ray
+}
+
+// CastCenterRay is like CastRay, except that the ray always originates at the center of the lens,
+// at the shutter open time. So, it is deterministic and unaffected by the depth of field effect.
+func (c *Camera) CastCenterRay(viewportX, viewportY float64) *utils.Ray {
+	return c.castRayFromLens(viewportX, viewportY, utils.NewVec3(0, 0, 0))
+}
+
+// castRayFromLens returns a Ray instance that originates at the given offset from the
+// camera's origin and goes toward the given xy location on the viewport.
+func (c *Camera) castRayFromLens(viewportX, viewportY float64, offset *utils.Vec3) *utils.Ray {
 	// Determine the direction of the ray for the given viewport xy.
-	rayDirection := c.lowerLeftCorner.
-		Add(c.horizontal.Mul(viewportX)).
-		Add(c.vertical.Mul(viewportY)).
-		Sub(c.origin).
-		Sub(offset).
-		Dir()
-
-	// Create the ray.
-	return utils.NewRay(c.origin.Add(offset), rayDirection)
+	// The intermediate vectors live on the stack, since this runs for every sample.
+	var rayDirection, scratch utils.Vec3
+	c.lowerLeftCorner.AddInto(c.horizontal.MulInto(viewportX, &scratch), &rayDirection)
+	rayDirection.AddInto(c.vertical.MulInto(viewportY, &scratch), &rayDirection)
+	rayDirection.SubInto(c.origin, &rayDirection)
+	rayDirection.SubInto(offset, &rayDirection)
+
+	// Create the ray. It normalizes the direction.
+	ray := utils.NewRay(c.origin.Add(offset), &rayDirection)
+	ray.Time = c.shutterOpen
+
+	return ray
+}
+
+// FocusDistance returns the distance of the plane of focus from the camera.
+// It is the resolved value, so it accounts for the AutoFocus option.
+func (c *Camera) FocusDistance() float64 {
+	return c.focusDistance
+}
+
+// Depth returns the distance of the given point from the camera, along the viewing direction.
+// The points with a depth equal to the FocusDistance are perfectly in focus.
+func (c *Camera) Depth(point *utils.Vec3) float64 {
+	// The camW vector points backward, away from the viewing direction.
+	return c.origin.Sub(point).Dot(c.camW)
+}
+
+// Project is the inverse of CastRay. It returns the xy location on the viewport, toward which
+// a ray must be cast from the center of the lens to pass through the given point.
+//
+// The visible flag is true only if the point is in front of the camera and inside the frame,
+// that is, both x and y are in the [0, 1] interval.
+func (c *Camera) Project(point *utils.Vec3) (x, y float64, visible bool) {
+	depth := c.Depth(point)
+	if depth <= 0 {
+		return 0, 0, false
+	}
+
+	// Scale the point's offset from the origin to bring it on the viewport, which lies at the focus distance.
+	onViewport := c.origin.Add(point.Sub(c.origin).Mul(c.focusDistance / depth))
+	fromCorner := onViewport.Sub(c.lowerLeftCorner)
+
+	x = fromCorner.Dot(c.horizontal) / c.horizontal.DotSelf()
+	y = fromCorner.Dot(c.vertical) / c.vertical.DotSelf()
+
+	return x, y, x >= 0 && x <= 1 && y >= 0 && y <= 1
 }
 
 // degreeToRadians converts the given degree value to radians.
 func degreeToRadians(deg float64) float64 {
 	return deg * math.Pi / 180
 }
+
+// Frame returns a copy of the given options, modified so that the camera sees the whole
+// axis-aligned box defined by the given min and max corners.
+//
+// The LookAt is placed at the center of the box and the LookFrom is moved back along the
+// current viewing direction (from LookAt toward LookFrom), far enough for the box to fit both
+// the vertical and horizontal field of view. The FocusDistance is set to keep the center sharp.
+func Frame(boxMin, boxMax *utils.Vec3, opts *Options) *Options {
+	framed := *opts
+
+	center := boxMin.Add(boxMax).Div(2)
+	// The box fits in the view if its bounding sphere does.
+	radius := boxMax.Sub(boxMin).Mag() / 2
+
+	// The narrower of the two fields of view limits the framing.
+	halfFOVVertical := degreeToRadians(opts.FieldOfViewVertical) / 2
+	halfFOVHorizontal := math.Atan(opts.AspectRatio * math.Tan(halfFOVVertical))
+	distance := radius / math.Sin(math.Min(halfFOVVertical, halfFOVHorizontal))
+
+	framed.LookAt = center
+	framed.LookFrom = center.Add(opts.LookFrom.Sub(opts.LookAt).Dir().Mul(distance))
+	framed.FocusDistance = distance
+
+	return &framed
+}
+
+// ErrCannotFit is returned by FitSphere if the sphere cannot be framed as requested.
+var ErrCannotFit = errors.New("cannot fit the sphere in the frame")
+
+// FitSphere returns a copy of the given options, modified so that the sphere with the given center
+// and radius fills the given fraction of the frame, as seen from the current LookFrom.
+//
+// The LookAt is placed at the center of the sphere, and the field of view is set so that the diameter
+// of the sphere is the given fraction of the narrower dimension of the frame. For example, a fraction of
+// 0.5 leaves a margin of a quarter of the frame on both sides. The FocusDistance is set to keep the center sharp.
+//
+// It returns an error wrapping ErrCannotFit if the LookFrom is not outside the sphere, since the sphere cannot
+// be framed from within, or if the radius or the fraction is not positive.
+func FitSphere(center *utils.Vec3, radius, fraction float64, opts *Options) (*Options, error) {
+	if radius <= 0 || fraction <= 0 {
+		return nil, fmt.Errorf("%w: the radius (%v) and the fraction (%v) must be positive",
+			ErrCannotFit, radius, fraction)
+	}
+
+	distance := opts.LookFrom.Sub(center).Mag()
+	if distance <= radius {
+		return nil, fmt.Errorf("%w: the LookFrom is %v from the center, within the radius of %v",
+			ErrCannotFit, distance, radius)
+	}
+
+	fitted := *opts
+	// The tangent of the angle that the sphere's radius subtends at the LookFrom.
+	// It is also the radius of the sphere's image on a viewport at unit distance.
+	tanRadius := math.Tan(math.Asin(radius / distance))
+
+	// The half-height of the viewport at unit distance, for which the sphere fills the fraction.
+	halfHeight := tanRadius / fraction
+	if opts.AspectRatio < 1 {
+		// The width is narrower, so it limits the framing.
+		halfHeight /= opts.AspectRatio
+	}
+
+	fitted.LookAt = center
+	fitted.FieldOfViewVertical = 2 * math.Atan(halfHeight) * 180 / math.Pi
+	fitted.FocusDistance = distance
+
+	return &fitted, nil
+}
@@ -0,0 +1,229 @@
+package camera
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// testOptions returns the options of a camera that looks at a point away from the origin.
+func testOptions() *Options {
+	return &Options{
+		LookFrom:            utils.NewVec3(13, 2, 3),
+		LookAt:              utils.NewVec3(0, 0.5, 0),
+		Up:                  utils.NewVec3(0, 1, 0),
+		AspectRatio:         16.0 / 9,
+		FieldOfViewVertical: 20,
+		Aperture:            0.1,
+		FocusDistance:       10,
+	}
+}
+
+func TestCamera_Project_LookAt(t *testing.T) {
+	opts := testOptions()
+	cam := New(opts)
+
+	x, y, visible := cam.Project(opts.LookAt)
+	if !visible {
+		t.Fatalf("expected the LookAt to be visible")
+	}
+	if math.Abs(x-0.5) > 1e-9 || math.Abs(y-0.5) > 1e-9 {
+		t.Fatalf("expected the LookAt at (0.5, 0.5), got (%v, %v)", x, y)
+	}
+}
+
+func TestCamera_Project_CastCenterRay(t *testing.T) {
+	cam := New(testOptions())
+
+	// Points along the rays through various viewport locations project back to those locations.
+	for _, viewport := range [][2]float64{{0.01, 0.02}, {0.99, 0.98}, {0.25, 0.75}, {0.9, 0.1}} {
+		ray := cam.CastCenterRay(viewport[0], viewport[1])
+		for _, distance := range []float64{0.5, 10, 100} {
+			x, y, visible := cam.Project(ray.At(distance))
+			if !visible {
+				t.Fatalf("expected the point at %v along the ray through %v to be visible", distance, viewport)
+			}
+			if math.Abs(x-viewport[0]) > 1e-9 || math.Abs(y-viewport[1]) > 1e-9 {
+				t.Fatalf("expected the point at %v along the ray through %v to project there, got (%v, %v)",
+					distance, viewport, x, y)
+			}
+		}
+	}
+}
+
+func TestCamera_Project_Invisible(t *testing.T) {
+	opts := testOptions()
+	cam := New(opts)
+
+	// The point behind the camera, opposite to the LookAt.
+	behind := opts.LookFrom.Add(opts.LookFrom.Sub(opts.LookAt))
+	if _, _, visible := cam.Project(behind); visible {
+		t.Errorf("expected the point behind the camera to be invisible")
+	}
+
+	// The point far to the side of the frame.
+	outside := cam.CastCenterRay(3, 0.5).At(10)
+	if _, _, visible := cam.Project(outside); visible {
+		t.Errorf("expected the point outside the frame to be invisible")
+	}
+}
+
+func TestFitSphere(t *testing.T) {
+	center, radius, fraction := utils.NewVec3(1, 0.5, -2), 0.75, 0.6
+
+	for _, aspectRatio := range []float64{16.0 / 9, 0.5} {
+		opts := testOptions()
+		opts.AspectRatio = aspectRatio
+		opts, err := FitSphere(center, radius, fraction, opts)
+		if err != nil {
+			t.Fatalf("aspect ratio %v: failed to fit the sphere: %v", aspectRatio, err)
+		}
+		cam := New(opts)
+
+		// The camera basis, with w pointing backward.
+		w := opts.LookFrom.Sub(center).Dir()
+		u := opts.Up.Cross(w).Dir()
+		v := w.Cross(u)
+
+		// The silhouette of the sphere is where the rays from the LookFrom touch it, at this angle from its center.
+		distance := opts.LookFrom.Sub(center).Mag()
+		angle := math.Asin(radius / distance)
+		tangent := func(axis *utils.Vec3) *utils.Vec3 {
+			direction := w.Mul(-math.Cos(angle)).Add(axis.Mul(math.Sin(angle)))
+			return opts.LookFrom.Add(direction.Mul(distance * math.Cos(angle)))
+		}
+
+		// The radius of the sphere's image, in viewport units, along both axes.
+		x, _, visibleX := cam.Project(tangent(u))
+		_, y, visibleY := cam.Project(tangent(v))
+		if !visibleX || !visibleY {
+			t.Fatalf("aspect ratio %v: expected the silhouette to be visible", aspectRatio)
+		}
+		radiusX, radiusY := math.Abs(x-0.5), math.Abs(y-0.5)
+
+		// The narrower dimension is filled by the fraction, and the other one proportionally less.
+		wantX, wantY := fraction/2/aspectRatio, fraction/2
+		if aspectRatio < 1 {
+			wantX, wantY = fraction/2, fraction/2*aspectRatio
+		}
+		if math.Abs(radiusX-wantX) > 1e-9 || math.Abs(radiusY-wantY) > 1e-9 {
+			t.Errorf("aspect ratio %v: expected the image radius (%v, %v), got (%v, %v)",
+				aspectRatio, wantX, wantY, radiusX, radiusY)
+		}
+
+		if math.Abs(cam.FocusDistance()-distance) > 1e-9 {
+			t.Errorf("aspect ratio %v: expected the focus distance %v, got %v", aspectRatio, distance, cam.FocusDistance())
+		}
+	}
+}
+
+func TestFitSphere_Invalid(t *testing.T) {
+	opts := testOptions()
+
+	tests := []struct {
+		name             string
+		center           *utils.Vec3
+		radius, fraction float64
+	}{
+		{name: "inside", center: opts.LookFrom.Add(utils.NewVec3(0.5, 0, 0)), radius: 1, fraction: 0.5},
+		{name: "on the surface", center: opts.LookFrom.Add(utils.NewVec3(1, 0, 0)), radius: 1, fraction: 0.5},
+		{name: "zero radius", center: utils.NewVec3(0, 0, 0), radius: 0, fraction: 0.5},
+		{name: "zero fraction", center: utils.NewVec3(0, 0, 0), radius: 1, fraction: 0},
+	}
+
+	for _, test := range tests {
+		fitted, err := FitSphere(test.center, test.radius, test.fraction, opts)
+		if !errors.Is(err, ErrCannotFit) || fitted != nil {
+			t.Errorf("%s: expected ErrCannotFit and no options, got %v and %v", test.name, err, fitted)
+		}
+	}
+}
+
+func TestCamera_AutoFocus(t *testing.T) {
+	opts := testOptions()
+	opts.Aperture = 0.5
+	opts.AutoFocus = true
+	cam := New(opts)
+
+	// The FocusDistance of the options is ignored for the distance of the LookAt.
+	distance := opts.LookFrom.Sub(opts.LookAt).Mag()
+	if math.Abs(cam.FocusDistance()-distance) > 1e-9 {
+		t.Fatalf("expected the focus distance %v, got %v", distance, cam.FocusDistance())
+	}
+
+	// The rays from all over the lens, toward the center of the frame, meet at the LookAt, which is sharp.
+	// At twice the distance, they spread out, which is the blur of the depth of field.
+	rng := random.New(1)
+	var farSpread float64
+	farCenter := cam.CastCenterRay(0.5, 0.5).At(2 * distance)
+	for i := 0; i < 100; i++ {
+		ray := cam.CastRay(0.5, 0.5, rng)
+		// The depth grows linearly along the ray, so the distance to the given depth is a simple ratio.
+		perDepth := 1 / (cam.Depth(ray.At(1)) - cam.Depth(ray.Origin))
+		if got := ray.At((distance - cam.Depth(ray.Origin)) * perDepth); !got.ApproxEqual(opts.LookAt, 1e-9) {
+			t.Fatalf("expected the ray from the lens at %v to pass through the LookAt, got %v", ray.Origin, got)
+		}
+		farSpread = math.Max(farSpread, ray.At((2*distance-cam.Depth(ray.Origin))*perDepth).Sub(farCenter).Mag())
+	}
+	if farSpread < 0.05 {
+		t.Errorf("expected the rays to spread out beyond the focus, got a spread of %v", farSpread)
+	}
+
+	// Without the AutoFocus, the FocusDistance of 10 is used, which is not the distance of the LookAt.
+	opts.AutoFocus = false
+	if got := New(opts).FocusDistance(); got != opts.FocusDistance {
+		t.Errorf("expected the focus distance %v without the AutoFocus, got %v", opts.FocusDistance, got)
+	}
+}
+
+func TestFrame(t *testing.T) {
+	boxMin, boxMax := utils.NewVec3(-3, 0, -1), utils.NewVec3(5, 2, 4)
+
+	for _, aspectRatio := range []float64{16.0 / 9, 1, 0.5} {
+		opts := testOptions()
+		opts.AspectRatio = aspectRatio
+		framed := Frame(boxMin, boxMax, opts)
+		cam := New(framed)
+
+		// The camera looks at the center of the box, from the same direction, and keeps it sharp.
+		center := utils.NewVec3(1, 1, 1.5)
+		if !framed.LookAt.ApproxEqual(center, 1e-9) {
+			t.Errorf("aspect ratio %v: expected the LookAt at the center %v, got %v", aspectRatio, center, framed.LookAt)
+		}
+		wantDir, gotDir := opts.LookFrom.Sub(opts.LookAt).Dir(), framed.LookFrom.Sub(framed.LookAt).Dir()
+		if !gotDir.ApproxEqual(wantDir, 1e-9) {
+			t.Errorf("aspect ratio %v: expected the viewing direction %v, got %v", aspectRatio, wantDir, gotDir)
+		}
+		if distance := framed.LookFrom.Sub(center).Mag(); math.Abs(cam.FocusDistance()-distance) > 1e-9 {
+			t.Errorf("aspect ratio %v: expected the focus distance %v, got %v", aspectRatio, distance, cam.FocusDistance())
+		}
+
+		// All eight corners are in view, and the box is not framed from too far to fill a good part of it.
+		minX, maxX, minY, maxY := 1.0, 0.0, 1.0, 0.0
+		for i := 0; i < 8; i++ {
+			corner := utils.NewVec3(boxMin.X, boxMin.Y, boxMin.Z)
+			if i&1 != 0 {
+				corner.X = boxMax.X
+			}
+			if i&2 != 0 {
+				corner.Y = boxMax.Y
+			}
+			if i&4 != 0 {
+				corner.Z = boxMax.Z
+			}
+
+			x, y, visible := cam.Project(corner)
+			if !visible {
+				t.Errorf("aspect ratio %v: expected the corner %v in view, got (%v, %v)", aspectRatio, corner, x, y)
+			}
+			minX, maxX, minY, maxY = math.Min(minX, x), math.Max(maxX, x), math.Min(minY, y), math.Max(maxY, y)
+		}
+		if maxX-minX < 0.3 && maxY-minY < 0.3 {
+			t.Errorf("aspect ratio %v: expected the box to fill a good part of the frame, got the extents %v and %v",
+				aspectRatio, maxX-minX, maxY-minY)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package camera
+
+import (
+	"errors"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// Interpolation determines how a Path moves between its keyframes.
+type Interpolation int
+
+const (
+	// InterpolationLinear moves in straight lines between the keyframes, at a constant speed.
+	InterpolationLinear Interpolation = iota
+	// InterpolationCatmullRom moves along a Catmull-Rom spline, which passes through all keyframes
+	// and changes direction smoothly at them. To know more, visit-
+	// https://en.wikipedia.org/wiki/Cubic_Hermite_spline#Catmull%E2%80%93Rom_spline
+	InterpolationCatmullRom
+)
+
+// Keyframe is the camera options at a point in time.
+type Keyframe struct {
+	// Time of the keyframe. For example, it can be in the [0, 1] interval for the whole animation.
+	Time float64
+	// Options of the camera at the time.
+	Options *Options
+}
+
+// Path is a camera motion through a list of keyframes, for animations.
+//
+// The LookFrom, LookAt and FieldOfViewVertical are interpolated between the keyframes.
+// All other options are taken from the keyframe just before the time.
+type Path struct {
+	// Keyframes of the path, sorted by their time. There must be at least one.
+	Keyframes []*Keyframe
+	// Interpolation between the keyframes. It defaults to InterpolationLinear.
+	Interpolation Interpolation
+}
+
+// ErrNoKeyframes is returned by NewPath if it is given no keyframes.
+var ErrNoKeyframes = errors.New("a camera path needs at least one keyframe")
+
+// NewPath returns a new Path through the given keyframes, which should be sorted by their time.
+// It returns ErrNoKeyframes if there are none.
+func NewPath(interpolation Interpolation, keyframes ...*Keyframe) (*Path, error) {
+	if len(keyframes) == 0 {
+		return nil, ErrNoKeyframes
+	}
+	return &Path{Keyframes: keyframes, Interpolation: interpolation}, nil
+}
+
+// At returns the camera at the given time.
+func (p *Path) At(time float64) *Camera {
+	return New(p.OptionsAt(time))
+}
+
+// OptionsAt returns the camera options at the given time.
+// Times before the first keyframe or after the last one give the options of that keyframe.
+//
+// It panics if the path has no keyframes, which NewPath does not allow.
+func (p *Path) OptionsAt(time float64) *Options {
+	if len(p.Keyframes) == 0 {
+		panic("camera: path without keyframes")
+	}
+
+	// Find the segment that contains the time.
+	last := len(p.Keyframes) - 1
+	if time <= p.Keyframes[0].Time {
+		return p.Keyframes[0].Options
+	}
+	if time >= p.Keyframes[last].Time {
+		return p.Keyframes[last].Options
+	}
+
+	// A time at a keyframe starts the segment after it, where the fraction is zero,
+	// so the keyframe is returned exactly by both interpolations.
+	segment := 0
+	for time >= p.Keyframes[segment+1].Time {
+		segment++
+	}
+
+	start, end := p.Keyframes[segment], p.Keyframes[segment+1]
+	// Fraction of the segment that is covered.
+	fraction := (time - start.Time) / (end.Time - start.Time)
+
+	// The neighbouring keyframes shape the spline. The end keyframes are repeated at the ends.
+	before, after := start, end
+	if segment > 0 {
+		before = p.Keyframes[segment-1]
+	}
+	if segment+2 <= last {
+		after = p.Keyframes[segment+2]
+	}
+
+	interpolate := func(get func(*Options) *utils.Vec3) *utils.Vec3 {
+		if p.Interpolation == InterpolationCatmullRom {
+			return catmullRom(get(before.Options), get(start.Options), get(end.Options), get(after.Options), fraction)
+		}
+		return get(start.Options).Lerp(get(end.Options), fraction)
+	}
+
+	opts := *start.Options
+	opts.LookFrom = interpolate(func(o *Options) *utils.Vec3 { return o.LookFrom })
+	opts.LookAt = interpolate(func(o *Options) *utils.Vec3 { return o.LookAt })
+	// The field of view is interpolated as the X component of a vector to reuse the same logic.
+	opts.FieldOfViewVertical = interpolate(func(o *Options) *utils.Vec3 {
+		return utils.NewVec3(o.FieldOfViewVertical, 0, 0)
+	}).X
+
+	return &opts
+}
+
+// catmullRom returns the point at the given fraction of the Catmull-Rom spline segment between p1 and p2,
+// where p0 and p3 are the neighbouring control points.
+func catmullRom(p0, p1, p2, p3 *utils.Vec3, fraction float64) *utils.Vec3 {
+	f2, f3 := fraction*fraction, fraction*fraction*fraction
+
+	// The formula is 0.5 * (2p1 + (p2 - p0)f + (2p0 - 5p1 + 4p2 - p3)f^2 + (3p1 - p0 - 3p2 + p3)f^3).
+	return p1.Mul(2).
+		Add(p2.Sub(p0).Mul(fraction)).
+		Add(p0.Mul(2).Sub(p1.Mul(5)).Add(p2.Mul(4)).Sub(p3).Mul(f2)).
+		Add(p1.Mul(3).Sub(p0).Sub(p2.Mul(3)).Add(p3).Mul(f3)).
+		Mul(0.5)
+}
@@ -0,0 +1,85 @@
+package camera
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// testPath returns a path through three keyframes with the given interpolation.
+func testPath(t *testing.T, interpolation Interpolation) *Path {
+	t.Helper()
+
+	keyframe := func(time float64, lookFrom, lookAt *utils.Vec3, fov float64) *Keyframe {
+		opts := testOptions()
+		opts.LookFrom, opts.LookAt, opts.FieldOfViewVertical = lookFrom, lookAt, fov
+		return &Keyframe{Time: time, Options: opts}
+	}
+
+	path, err := NewPath(interpolation,
+		keyframe(0, utils.NewVec3(13, 2, 3), utils.NewVec3(0, 0.5, 0), 20),
+		keyframe(0.3, utils.NewVec3(-1.7, 4.1, 9.3), utils.NewVec3(0.2, 0.7, -0.1), 35),
+		keyframe(1, utils.NewVec3(-12, 1, -4.6), utils.NewVec3(0.1, 0.3, 0.9), 27.5),
+	)
+	if err != nil {
+		t.Fatalf("failed to create the path: %v", err)
+	}
+	return path
+}
+
+func TestPath_Keyframes(t *testing.T) {
+	for _, interpolation := range []Interpolation{InterpolationLinear, InterpolationCatmullRom} {
+		path := testPath(t, interpolation)
+
+		for idx, keyframe := range path.Keyframes {
+			got, want := path.OptionsAt(keyframe.Time), keyframe.Options
+			if *got.LookFrom != *want.LookFrom || *got.LookAt != *want.LookAt ||
+				got.FieldOfViewVertical != want.FieldOfViewVertical {
+				t.Errorf("interpolation %v: expected keyframe %d exactly, got %+v", interpolation, idx, got)
+			}
+		}
+	}
+}
+
+func TestPath_Midpoints(t *testing.T) {
+	path := testPath(t, InterpolationLinear)
+
+	// Linear midpoints are the averages of the keyframes around them.
+	for segment := 0; segment < len(path.Keyframes)-1; segment++ {
+		start, end := path.Keyframes[segment], path.Keyframes[segment+1]
+		got := path.OptionsAt((start.Time + end.Time) / 2)
+
+		wantLookFrom := start.Options.LookFrom.Add(end.Options.LookFrom).Div(2)
+		wantFov := (start.Options.FieldOfViewVertical + end.Options.FieldOfViewVertical) / 2
+		if !got.LookFrom.ApproxEqual(wantLookFrom, 1e-9) || math.Abs(got.FieldOfViewVertical-wantFov) > 1e-9 {
+			t.Errorf("segment %d: expected the midpoint %v with fov %v, got %v with fov %v",
+				segment, wantLookFrom, wantFov, got.LookFrom, got.FieldOfViewVertical)
+		}
+	}
+
+	// The Catmull-Rom spline stays within the span of the keyframes, and differs from the straight line.
+	curved := testPath(t, InterpolationCatmullRom).OptionsAt(0.15)
+	straight := path.OptionsAt(0.15)
+	if curved.LookFrom.ApproxEqual(straight.LookFrom, 1e-3) {
+		t.Errorf("expected the spline to curve away from the straight line, got %v", curved.LookFrom)
+	}
+	if fov := curved.FieldOfViewVertical; fov < 20 || fov > 35 {
+		t.Errorf("expected the fov between the keyframes, got %v", fov)
+	}
+
+	// Times beyond the ends give the end keyframes.
+	if got := path.OptionsAt(-1); got != path.Keyframes[0].Options {
+		t.Errorf("expected the first keyframe before the start")
+	}
+	if got := path.OptionsAt(2); got != path.Keyframes[2].Options {
+		t.Errorf("expected the last keyframe after the end")
+	}
+}
+
+func TestNewPath_Empty(t *testing.T) {
+	if path, err := NewPath(InterpolationLinear); !errors.Is(err, ErrNoKeyframes) || path != nil {
+		t.Errorf("expected ErrNoKeyframes and no path, got %v and %v", err, path)
+	}
+}
@@ -0,0 +1,74 @@
+package mats
+
+import (
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/textures"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// bumpDelta is the step in the UV space, over which the gradient of a bump map's heights is calculated.
+const bumpDelta = 1e-3
+
+// BumpMapped implements the material interface by tilting the normals of another material according to
+// a height texture, which gives the appearance of bumps and dents on a smooth surface without any geometry.
+//
+// The height at every point is the luminance of the texture there. The normal tilts away from the direction
+// in which the height grows, as calculated by finite differences in the UV space, so the shapes need UV
+// coordinates and tangents. Elsewhere, the normal is left as it is.
+// To know more, visit-
+// https://www.pbr-book.org/3ed-2018/Materials/Bump_Mapping
+type BumpMapped struct {
+	// Inner is the material that scatters the rays with the tilted normals.
+	Inner Material
+	// Heights is the texture whose luminance gives the height of the surface.
+	Heights textures.Texture
+	// Strength scales the tilt of the normals. Zero leaves them as they are, and negative values invert the bumps.
+	Strength float64
+}
+
+// NewBumpMapped returns a new BumpMapped material.
+func NewBumpMapped(inner Material, heights textures.Texture, strength float64) *BumpMapped {
+	return &BumpMapped{Inner: inner, Heights: heights, Strength: strength}
+}
+
+func (b *BumpMapped) Scatter(ray *utils.Ray, hitInfo *RayHit, rng *random.Generator) (*utils.Ray, *utils.Colour, bool) {
+	scattered, attenuation, _, isScattered := b.ScatterLobe(ray, hitInfo, rng)
+	return scattered, attenuation, isScattered
+}
+
+// ScatterLobe is like Scatter. The ray gets the lobe that the inner material scatters it by.
+func (b *BumpMapped) ScatterLobe(ray *utils.Ray, hitInfo *RayHit, rng *random.Generator,
+) (*utils.Ray, *utils.Colour, Lobe, bool) {
+	bumped := *hitInfo
+	bumped.Normal = b.Normal(hitInfo)
+	return ScatterWithLobe(b.Inner, ray, &bumped, rng)
+}
+
+// Normal returns the tilted normal at the point-of-hit, on the same side as the normal of the hit.
+func (b *BumpMapped) Normal(hitInfo *RayHit) *utils.Vec3 {
+	tangent := &hitInfo.Tangent
+	if tangent.IsNearZero() || b.Strength == 0 {
+		return hitInfo.Normal
+	}
+
+	// The gradient of the heights in the UV space, by central differences.
+	height := func(u, v float64) float64 { return b.Heights.Value(u, v, hitInfo.Point).Luminance() }
+	slopeU := (height(hitInfo.U+bumpDelta, hitInfo.V) - height(hitInfo.U-bumpDelta, hitInfo.V)) / (2 * bumpDelta)
+	slopeV := (height(hitInfo.U, hitInfo.V+bumpDelta) - height(hitInfo.U, hitInfo.V-bumpDelta)) / (2 * bumpDelta)
+	if slopeU == 0 && slopeV == 0 {
+		return hitInfo.Normal
+	}
+
+	// The tangent frame is built around the outward normal, which gives the direction in which V grows.
+	outward := hitInfo.Normal
+	if !hitInfo.IsRayOutside {
+		outward = outward.Mul(-1)
+	}
+	bitangent := outward.Cross(tangent)
+
+	tilted := outward.Sub(tangent.Mul(b.Strength * slopeU)).Sub(bitangent.Mul(b.Strength * slopeV)).Dir()
+	if !hitInfo.IsRayOutside {
+		tilted = tilted.Mul(-1)
+	}
+	return tilted
+}
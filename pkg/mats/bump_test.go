@@ -0,0 +1,58 @@
+package mats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// rampTexture is a height texture that rises along U up to the middle, and is flat after it.
+type rampTexture struct{}
+
+func (rampTexture) Value(u, _ float64, _ *utils.Vec3) *utils.Colour {
+	height := math.Min(u, 0.5)
+	return utils.NewColour(height, height, height)
+}
+
+func TestBumpMapped_Normal(t *testing.T) {
+	bump := NewBumpMapped(NewMatte(utils.NewColour(0.5, 0.5, 0.5)), rampTexture{}, 0.5)
+	up := utils.NewVec3(0, 0, 1)
+
+	tests := []struct {
+		name    string
+		u       float64
+		outside bool
+		want    *utils.Vec3
+	}{
+		// The height grows along the tangent, so the normal tilts against it.
+		{name: "slope", u: 0.25, outside: true, want: utils.NewVec3(-0.5, 0, 1).Dir()},
+		{name: "slope from inside", u: 0.25, outside: false, want: utils.NewVec3(0.5, 0, -1).Dir()},
+		{name: "flat", u: 0.75, outside: true, want: up},
+		{name: "flat from inside", u: 0.75, outside: false, want: up.Mul(-1)},
+	}
+
+	for _, test := range tests {
+		hitInfo := &RayHit{
+			Point:        utils.NewVec3(test.u, 0.5, 0),
+			Normal:       up,
+			IsRayOutside: test.outside,
+			U:            test.u,
+			V:            0.5,
+			Tangent:      *utils.NewVec3(1, 0, 0),
+		}
+		if !test.outside {
+			hitInfo.Normal = up.Mul(-1)
+		}
+
+		if got := bump.Normal(hitInfo); !got.ApproxEqual(test.want, 1e-9) {
+			t.Errorf("%s: expected the normal %v, got %v", test.name, test.want, got)
+		}
+	}
+
+	// Without a tangent, the normal cannot be tilted.
+	hitInfo := &RayHit{Normal: up, IsRayOutside: true, U: 0.25, V: 0.5}
+	if got := bump.Normal(hitInfo); got != up {
+		t.Errorf("expected the normal to be unchanged without a tangent, got %v", got)
+	}
+}
@@ -0,0 +1,54 @@
+package mats
+
+import (
+	"math"
+
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// Coated implements the material interface as a base material under a glossy
+// dielectric coat, like car paint or varnished wood.
+//
+// For every ray, the Fresnel reflectance of the coat decides whether the ray reflects
+// off the coat or passes through it to be scattered by the base material.
+type Coated struct {
+	// Base is the material under the coat.
+	Base Material
+	// CoatIOR is the refractive index of the coat.
+	CoatIOR float64
+	// CoatRoughness represents how fuzzy the coat reflections should look.
+	// It works the same way as the Fuzz of the Metallic material.
+	CoatRoughness float64
+}
+
+// NewCoated returns a new Coated material.
+func NewCoated(base Material, coatIOR, coatRoughness float64) *Coated {
+	return &Coated{Base: base, CoatIOR: coatIOR, CoatRoughness: coatRoughness}
+}
+
+func (c *Coated) Scatter(ray *utils.Ray, hitInfo *RayHit, rng *random.Generator) (*utils.Ray, *utils.Colour, bool) {
+	scattered, attenuation, _, isScattered := c.ScatterLobe(ray, hitInfo, rng)
+	return scattered, attenuation, isScattered
+}
+
+// ScatterLobe is like Scatter. The reflections off the coat are specular,
+// while the rays that reach the base material get the lobe that it scatters them by.
+func (c *Coated) ScatterLobe(ray *utils.Ray, hitInfo *RayHit, rng *random.Generator,
+) (*utils.Ray, *utils.Colour, Lobe, bool) {
+	// Safely calculating the cosine of the angle of incidence.
+	cosine := math.Min(ray.Dir.Mul(-1).Dot(hitInfo.Normal), 1)
+
+	// Rays that are not reflected by the coat reach the base material.
+	// The coat itself is clear, so it does not attenuate them.
+	if schlickApprox(cosine, 1/c.CoatIOR) <= rng.Float() {
+		return ScatterWithLobe(c.Base, ray, hitInfo, rng)
+	}
+
+	// Glossy reflection off the coat, exactly like a white metal.
+	reflected := ray.Dir.Reflected(hitInfo.Normal).Dir()
+	scatteredDir := reflected.Add(rng.Vec3InUnitSphere().Mul(c.CoatRoughness)).Dir()
+	scattered := utils.NewRay(hitInfo.Point, scatteredDir)
+
+	return scattered, utils.NewColour(1, 1, 1), LobeSpecular, scatteredDir.Dot(hitInfo.Normal) > 0
+}
@@ -0,0 +1,50 @@
+package mats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestCoated_Fresnel(t *testing.T) {
+	coated := NewCoated(NewMatte(utils.NewColour(0.5, 0.5, 0.5)), 1.5, 0)
+	hitInfo := &RayHit{Point: utils.NewVec3(0, 0, 0), Normal: utils.NewVec3(0, 1, 0), IsRayOutside: true}
+
+	// The fraction of the rays, arriving at the given angle from the normal, that the coat reflects.
+	coatReflectance := func(degrees float64) float64 {
+		radians := degrees * math.Pi / 180
+		ray := utils.NewRay(utils.NewVec3(-math.Sin(radians), math.Cos(radians), 0),
+			utils.NewVec3(math.Sin(radians), -math.Cos(radians), 0))
+		rng := random.New(9)
+
+		var specular int
+		const samples = 20000
+		for i := 0; i < samples; i++ {
+			if _, _, lobe, _ := coated.ScatterLobe(ray, hitInfo, rng); lobe == LobeSpecular {
+				specular++
+			}
+		}
+		return float64(specular) / samples
+	}
+
+	// At the normal incidence, the coat of IOR 1.5 reflects ((1.5-1)/(1.5+1))² = 4% of the light.
+	if got := coatReflectance(0); math.Abs(got-0.04) > 0.01 {
+		t.Errorf("expected the coat to reflect about 4%% at the normal incidence, got %v", got)
+	}
+
+	// The reflections grow towards grazing angles, where the coat acts like a mirror.
+	var previous float64
+	for _, degrees := range []float64{0, 45, 70, 85} {
+		got := coatReflectance(degrees)
+		if got < previous {
+			t.Errorf("expected the coat reflectance to grow towards grazing angles, got %v at %v degrees after %v",
+				got, degrees, previous)
+		}
+		previous = got
+	}
+	if previous < 0.4 {
+		t.Errorf("expected the coat to reflect a lot at grazing angles, got %v", previous)
+	}
+}
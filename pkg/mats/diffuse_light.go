@@ -0,0 +1,42 @@
+package mats
+
+import (
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/textures"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// DiffuseLight implements the material interface as a light source that emits the same colour
+// in all directions from the front (outer) face of the shape. It does not scatter any rays.
+type DiffuseLight struct {
+	// Emission is the colour of the emitted light. Its components can exceed 1 for brighter lights.
+	Emission *utils.Colour
+	// Texture, if provided, gives the emitted colour at every point instead of the Emission,
+	// so the light can show a pattern, like a TV screen. It uses the UV coordinates of the hit.
+	Texture textures.Texture
+}
+
+// NewDiffuseLight returns a new DiffuseLight material instance.
+func NewDiffuseLight(emission *utils.Colour) *DiffuseLight {
+	return &DiffuseLight{Emission: emission}
+}
+
+// NewTexturedLight returns a new DiffuseLight material instance that emits the given texture.
+func NewTexturedLight(texture textures.Texture) *DiffuseLight {
+	return &DiffuseLight{Texture: texture}
+}
+
+func (d *DiffuseLight) Scatter(*utils.Ray, *RayHit, *random.Generator) (*utils.Ray, *utils.Colour, bool) {
+	return nil, nil, false
+}
+
+func (d *DiffuseLight) Emit(_ *utils.Ray, hitInfo *RayHit) *utils.Colour {
+	// The back face is dark.
+	if !hitInfo.IsRayOutside {
+		return utils.NewColour(0, 0, 0)
+	}
+	if d.Texture != nil {
+		return d.Texture.Value(hitInfo.U, hitInfo.V, hitInfo.Point)
+	}
+	return d.Emission
+}
@@ -0,0 +1,46 @@
+package mats
+
+import (
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/textures"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestDiffuseLight_Texture(t *testing.T) {
+	bright, dim := utils.NewColour(4, 4, 4), utils.NewColour(0.5, 0.25, 0)
+	light := NewTexturedLight(textures.NewChecker(bright, dim, 2))
+	ray := utils.NewRay(utils.NewVec3(0, 1, 0), utils.NewVec3(0, -1, 0))
+
+	tests := []struct {
+		name string
+		u, v float64
+		want *utils.Colour
+	}{
+		{name: "first cell", u: 0.25, v: 0.25, want: bright},
+		{name: "next cell along U", u: 0.75, v: 0.25, want: dim},
+		{name: "next cell along V", u: 0.25, v: 0.75, want: dim},
+		{name: "diagonal cell", u: 0.75, v: 0.75, want: bright},
+	}
+
+	for _, test := range tests {
+		hitInfo := &RayHit{Point: utils.NewVec3(0, 0, 0), Normal: utils.NewVec3(0, 1, 0), IsRayOutside: true,
+			U: test.u, V: test.v}
+		if got := light.Emit(ray, hitInfo); !got.ApproxEqual(test.want, 0) {
+			t.Errorf("%s: expected the emission %v, got %v", test.name, test.want, got)
+		}
+
+		// The back face stays dark, whatever the texture.
+		hitInfo.IsRayOutside = false
+		if got := light.Emit(ray, hitInfo); !got.ApproxEqual(utils.NewColour(0, 0, 0), 0) {
+			t.Errorf("%s: expected the back face to be dark, got %v", test.name, got)
+		}
+	}
+
+	// The texture takes the place of the fixed emission.
+	light.Emission = utils.NewColour(1, 0, 0)
+	hitInfo := &RayHit{Point: utils.NewVec3(0, 0, 0), Normal: utils.NewVec3(0, 1, 0), IsRayOutside: true}
+	if got := light.Emit(ray, hitInfo); !got.ApproxEqual(bright, 0) {
+		t.Errorf("expected the texture to override the emission, got %v", got)
+	}
+}
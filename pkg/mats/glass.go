@@ -12,6 +12,19 @@ type Glass struct {
 	// RefractiveIndex of the material.
 	// For reference, RI if 1 for air, 1.3-1.7 for glass and 2.4 for diamond.
 	RefractiveIndex float64
+	// ExactFresnel makes the material use the full Fresnel equations instead of
+	// Schlick's approximation, which is inaccurate at grazing angles for high refractive indices.
+	ExactFresnel bool
+	// Dispersion makes the refractive index differ per colour channel, producing prismatic (rainbow) fringes.
+	// The red, green and blue channels use RefractiveIndex-Dispersion, RefractiveIndex and
+	// RefractiveIndex+Dispersion respectively. Small values like 0.02 look realistic. Zero disables it.
+	//
+	// A ray of white light picks one channel at random at its first dispersive hit, which the rest
+	// of its path keeps (as the Channel of the ray), so that all later hits refract the same colour.
+	//
+	// To know more, visit-
+	// https://en.wikipedia.org/wiki/Dispersion_(optics)
+	Dispersion float64
 }
 
 // NewGlass returns a new Glass material instance.
@@ -19,34 +32,112 @@ func NewGlass(ri float64) *Glass {
 	return &Glass{RefractiveIndex: ri}
 }
 
-func (g *Glass) Scatter(ray *utils.Ray, hitInfo *RayHit) (*utils.Ray, *utils.Colour, bool) {
+func (g *Glass) Scatter(ray *utils.Ray, hitInfo *RayHit, rng *random.Generator) (*utils.Ray, *utils.Colour, bool) {
 	// This method uses the physics of Total Internal Reflection and Schlick's approximation.
 	// To know more, visit-
 	// https://raytracing.github.io/books/RayTracingInOneWeekend.html#dielectrics/refraction
 
+	// With dispersion, the ray traces a single channel with its own refractive index.
+	// A ray of white light picks it at random, weighted by 3 to make up for the other two that are dropped.
+	ri, attenuation, channel := g.RefractiveIndex, utils.NewColour(1, 1, 1), ray.Channel
+	if g.Dispersion != 0 {
+		if channel == 0 {
+			channel = 1 + int(rng.Float()*3)
+			attenuation = channelColour(channel)
+		}
+		ri = g.channelIndex(channel)
+	}
+
 	// rir is the Refractive Index Ratio.
-	rir := g.RefractiveIndex
+	rir := ri
 	if hitInfo.IsRayOutside {
 		rir = 1 / rir
 	}
 
-	// Safely calculating cosine and sine.
+	// Safely calculating the cosine of the angle of incidence.
 	cosine := math.Min(ray.Dir.Mul(-1).Dot(hitInfo.Normal), 1)
-	sine := math.Sqrt(1 - cosine*cosine)
-
-	// The material cannot refract when the value of sine(thetaPrime) goes above 1,
-	// where thetaPrime is the angle of refraction.
-	cannotRefract := rir*sine > 1
 
 	// Determine whether the ray will be reflected or refracted.
-	var scatterDir *utils.Vec3
-	if cannotRefract || schlickApprox(cosine, rir) > random.Float() {
+	// The ray is always reflected in case of total internal reflection.
+	scatterDir, canRefract := ray.Dir.RefractedChecked(hitInfo.Normal, rir)
+	if !canRefract || g.reflectance(cosine, rir) > rng.Float() {
 		scatterDir = ray.Dir.Reflected(hitInfo.Normal)
-	} else {
-		scatterDir = ray.Dir.Refracted(hitInfo.Normal, rir)
 	}
 
-	return utils.NewRay(hitInfo.Point, scatterDir), utils.NewColour(1, 1, 1), true
+	scattered := utils.NewRay(hitInfo.Point, scatterDir)
+	scattered.Channel = channel
+
+	return scattered, attenuation, true
+}
+
+// Split returns both the reflected and the refracted rays, along with the reflectance.
+// It does not split the rays of dispersive glass, since their colour channels refract differently.
+func (g *Glass) Split(ray *utils.Ray, hitInfo *RayHit) (*utils.Ray, *utils.Ray, float64, bool) {
+	if g.Dispersion != 0 {
+		return nil, nil, 0, false
+	}
+
+	rir := g.RefractiveIndex
+	if hitInfo.IsRayOutside {
+		rir = 1 / rir
+	}
+
+	cosine := math.Min(ray.Dir.Mul(-1).Dot(hitInfo.Normal), 1)
+	reflected := utils.NewRay(hitInfo.Point, ray.Dir.Reflected(hitInfo.Normal))
+
+	refractedDir, canRefract := ray.Dir.RefractedChecked(hitInfo.Normal, rir)
+	if !canRefract {
+		return reflected, nil, 1, true
+	}
+
+	return reflected, utils.NewRay(hitInfo.Point, refractedDir), g.reflectance(cosine, rir), true
+}
+
+// channelIndex returns the refractive index for the given colour channel (1 for red, 2 for green, 3 for blue)
+// under dispersion.
+func (g *Glass) channelIndex(channel int) float64 {
+	return g.RefractiveIndex + float64(channel-2)*g.Dispersion
+}
+
+// channelColour returns the attenuation that keeps only the given colour channel, weighted by 3.
+func channelColour(channel int) *utils.Colour {
+	switch channel {
+	case 1:
+		return utils.NewColour(3, 0, 0)
+	case 2:
+		return utils.NewColour(0, 3, 0)
+	default:
+		return utils.NewColour(0, 0, 3)
+	}
+}
+
+// reflectance returns the fraction of light that the material reflects for the given
+// angle of incidence (cosine) and refractive index ratio (rir).
+func (g *Glass) reflectance(cosine, rir float64) float64 {
+	if g.ExactFresnel {
+		return fresnel(cosine, rir)
+	}
+	return schlickApprox(cosine, rir)
+}
+
+// fresnel calculates the reflectance of a dielectric material for the given angle of
+// incidence (cosine) and refractive index ratio (rir) using the full Fresnel equations.
+// The light is assumed to be unpolarized, so the s and p polarized reflectances are averaged.
+//
+// To know more, visit-
+// https://en.wikipedia.org/wiki/Fresnel_equations#Power_(intensity)_reflection_and_transmission_coefficients
+func fresnel(cosine, rir float64) float64 {
+	sineT := rir * math.Sqrt(1-cosine*cosine)
+	// Total internal reflection.
+	if sineT >= 1 {
+		return 1
+	}
+
+	cosineT := math.Sqrt(1 - sineT*sineT)
+	rs := (rir*cosine - cosineT) / (rir*cosine + cosineT)
+	rp := (cosine - rir*cosineT) / (cosine + rir*cosineT)
+
+	return (rs*rs + rp*rp) / 2
 }
 
 // schlickApprox approximates the reflectance of a dielectric material for the given
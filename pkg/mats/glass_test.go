@@ -0,0 +1,94 @@
+package mats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// rayAt returns a ray that arrives at the origin at the given angle from the +Y normal.
+func rayAt(degrees float64) *utils.Ray {
+	radians := degrees * math.Pi / 180
+	return utils.NewRay(utils.NewVec3(-math.Sin(radians), math.Cos(radians), 0),
+		utils.NewVec3(math.Sin(radians), -math.Cos(radians), 0))
+}
+
+func TestGlass_ExactFresnel(t *testing.T) {
+	glass := &Glass{RefractiveIndex: 1.5, ExactFresnel: true}
+	outside := &RayHit{Point: utils.NewVec3(0, 0, 0), Normal: utils.NewVec3(0, 1, 0), IsRayOutside: true}
+
+	// The unpolarised reflectance of an air-glass (n = 1.5) interface, averaged over the s and p polarisations.
+	// To know more, visit-
+	// https://en.wikipedia.org/wiki/Fresnel_equations#Power_(intensity)_reflection_and_transmission_coefficients
+	references := []struct{ degrees, reflectance float64 }{
+		{0, 0.0400}, {30, 0.0415}, {45, 0.0502}, {60, 0.0892}, {80, 0.3877},
+	}
+	for _, ref := range references {
+		_, refracted, reflectance, isSplit := glass.Split(rayAt(ref.degrees), outside)
+		if !isSplit || refracted == nil {
+			t.Fatalf("expected the ray at %v degrees to split into a reflection and a refraction", ref.degrees)
+		}
+		if math.Abs(reflectance-ref.reflectance) > 1e-4 {
+			t.Errorf("expected the reflectance %v at %v degrees, got %v", ref.reflectance, ref.degrees, reflectance)
+		}
+	}
+
+	// At the Brewster angle, the p-polarised light is not reflected at all, which leaves half the s reflectance.
+	brewster := math.Atan(1.5) * 180 / math.Pi
+	if _, _, got, _ := glass.Split(rayAt(brewster), outside); math.Abs(got-0.1479/2) > 1e-4 {
+		t.Errorf("expected the reflectance %v at the Brewster angle, got %v", 0.1479/2, got)
+	}
+
+	// Schlick's approximation is close, but not exact, away from the normal incidence.
+	_, _, schlick, _ := NewGlass(1.5).Split(rayAt(60), outside)
+	if math.Abs(schlick-0.0892) < 1e-3 {
+		t.Errorf("expected Schlick's approximation to differ from the exact reflectance, got %v", schlick)
+	}
+
+	// From inside, beyond the critical angle of about 41.8 degrees, all the light is reflected.
+	inside := &RayHit{Point: utils.NewVec3(0, 0, 0), Normal: utils.NewVec3(0, 1, 0), IsRayOutside: false}
+	if _, refracted, got, _ := glass.Split(rayAt(45), inside); refracted != nil || got != 1 {
+		t.Errorf("expected the total internal reflection from inside at 45 degrees, got a reflectance of %v", got)
+	}
+}
+
+func TestGlass_Dispersion(t *testing.T) {
+	hitInfo := &RayHit{Point: utils.NewVec3(0, 0, 0), Normal: utils.NewVec3(0, 1, 0), IsRayOutside: true}
+	rng := random.New(13)
+
+	// The direction in which the glass refracts a ray of the given colour channel at 45 degrees.
+	refracted := func(glass *Glass, channel int) *utils.Vec3 {
+		ray := rayAt(45)
+		ray.Channel = channel
+		for {
+			scattered, _, _ := glass.Scatter(ray, hitInfo, rng)
+			if scattered.Channel != channel {
+				t.Fatalf("expected the scattered ray to keep the channel %d, got %d", channel, scattered.Channel)
+			}
+			// Skip the reflections.
+			if scattered.Dir.Y < 0 {
+				return scattered.Dir.Dir()
+			}
+		}
+	}
+
+	const red, blue = 1, 3
+
+	// With the dispersion, blue bends more than red, towards the normal.
+	prism := &Glass{RefractiveIndex: 1.5, Dispersion: 0.02}
+	redDir, blueDir := refracted(prism, red), refracted(prism, blue)
+	if redDir.ApproxEqual(blueDir, 1e-6) {
+		t.Errorf("expected red and blue to refract differently with the dispersion, both got %v", redDir)
+	}
+	if blueDir.X >= redDir.X {
+		t.Errorf("expected blue %v to bend more than red %v", blueDir, redDir)
+	}
+
+	// Without it, they refract the same way.
+	plain := NewGlass(1.5)
+	if redDir, blueDir := refracted(plain, red), refracted(plain, blue); !redDir.ApproxEqual(blueDir, 1e-12) {
+		t.Errorf("expected red and blue to refract the same way without the dispersion, got %v and %v", redDir, blueDir)
+	}
+}
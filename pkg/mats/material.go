@@ -1,6 +1,7 @@
 package mats
 
 import (
+	"github.com/shivanshkc/lightshow/pkg/random"
 	"github.com/shivanshkc/lightshow/pkg/utils"
 )
 
@@ -13,10 +14,73 @@ type Material interface {
 	// The return values include the scattered ray, the attenuation of the
 	// material and a flag that tells whether the ray was scattered at all.
 	// If a ray is not scattered, the material at that point should appear black.
-	Scatter(ray *utils.Ray, hitInfo *RayHit,
+	//
+	// All randomness must come from the given Generator, so that concurrent
+	// calls do not contend over a shared one.
+	Scatter(ray *utils.Ray, hitInfo *RayHit, rng *random.Generator,
 	) (scattered *utils.Ray, attenuation *utils.Colour, isScattered bool)
 }
 
+// Emitter is implemented by the materials that emit light, in addition to scattering it.
+type Emitter interface {
+	// Emit returns the light emitted by the material at the point-of-hit,
+	// toward the origin of the given ray.
+	Emit(ray *utils.Ray, hitInfo *RayHit) *utils.Colour
+}
+
+// Splitter is implemented by the materials that both reflect and refract light, like glass.
+// It lets the renderer trace both rays, instead of choosing one at random, which reduces noise.
+type Splitter interface {
+	// Split returns the reflected and refracted rays for the inbound ray, and the fraction of light
+	// that is reflected. The refracted ray is nil in case of total internal reflection.
+	// The isSplit flag is false if the material cannot split the given ray deterministically.
+	Split(ray *utils.Ray, hitInfo *RayHit) (reflected, refracted *utils.Ray, reflectance float64, isSplit bool)
+}
+
+// Lobe is the kind of scattering that a ray went through, like a diffuse bounce or a mirror reflection.
+type Lobe int
+
+const (
+	// LobeSpecular covers the mirror-like and glossy reflections, and the refractions.
+	LobeSpecular Lobe = iota
+	// LobeDiffuse covers the diffuse bounces, which scatter the rays all over the hemisphere.
+	LobeDiffuse
+)
+
+// LobeScatterer is implemented by the materials that can tell the lobe of every scatter,
+// like the ones that combine diffuse and specular lobes. It lets the renderer budget the bounces by their kind.
+type LobeScatterer interface {
+	// ScatterLobe is like Scatter, but also returns the lobe that scattered the ray.
+	ScatterLobe(ray *utils.Ray, hitInfo *RayHit, rng *random.Generator,
+	) (scattered *utils.Ray, attenuation *utils.Colour, lobe Lobe, isScattered bool)
+}
+
+// ScatterWithLobe scatters the ray using the given material, and returns the lobe that scattered it.
+//
+// The materials that do not implement LobeScatterer have a single lobe, which is LobeDiffuse
+// for the ones that implement Diffuser and LobeSpecular for all others.
+func ScatterWithLobe(mat Material, ray *utils.Ray, hitInfo *RayHit, rng *random.Generator,
+) (*utils.Ray, *utils.Colour, Lobe, bool) {
+	if scatterer, ok := mat.(LobeScatterer); ok {
+		return scatterer.ScatterLobe(ray, hitInfo, rng)
+	}
+
+	lobe := LobeSpecular
+	if _, ok := mat.(Diffuser); ok {
+		lobe = LobeDiffuse
+	}
+
+	scattered, attenuation, isScattered := mat.Scatter(ray, hitInfo, rng)
+	return scattered, attenuation, lobe, isScattered
+}
+
+// Diffuser is implemented by the materials with a diffuse (Lambertian) surface,
+// which the renderer can light directly, as with a SunLight.
+type Diffuser interface {
+	// DiffuseAlbedo returns the colour of the diffuse surface at the point-of-hit.
+	DiffuseAlbedo(hitInfo *RayHit) *utils.Colour
+}
+
 // RayHit encapsulates the information regarding a ray hit.
 // TODO: Is this the correct package for this struct?
 type RayHit struct {
@@ -34,6 +98,22 @@ type RayHit struct {
 	// https://raytracing.github.io/books/RayTracingInOneWeekend.html#surfacenormalsandmultipleobjects/frontfacesversusbackfaces
 	IsRayOutside bool
 
+	// U and V are the surface (texture) coordinates of the point-of-hit, in the [0, 1] interval.
+	// They are zero for the shapes that do not support them.
+	U, V float64
+	// Tangent is the unit vector along the surface in which U grows, at the point-of-hit.
+	// Along with the outward normal, it gives the direction in which V grows, as the outward normal × Tangent.
+	// It is zero for the shapes without UV coordinates, and where the direction is undefined, like at the poles.
+	// It is held by value, so that the hits do not need another allocation for it.
+	Tangent utils.Vec3
+
+	// Bias is the minimum distance at which the rays leaving the point-of-hit register hits.
+	// It avoids self-intersections (shadow acne) at the scale of the hit shape.
+	// Zero means the renderer's default.
+	Bias float64
+
 	// Mat is the material of the shape.
 	Mat Material
+	// ID of the shape that was hit. It is used for the object-ID pass.
+	ID int
 }
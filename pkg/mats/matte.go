@@ -1,13 +1,38 @@
 package mats
 
 import (
+	"math"
+
 	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/textures"
 	"github.com/shivanshkc/lightshow/pkg/utils"
 )
 
+// ScatterModel determines how a Matte material picks the directions of the scattered rays.
+// All models give the same image on convergence, but they differ in noise and speed.
+type ScatterModel int
+
+const (
+	// ScatterLambertian adds a random unit vector to the normal, which is cheap and cosine-weighted.
+	// To know more, visit-
+	// https://raytracing.github.io/books/RayTracingInOneWeekend.html#diffusematerials/truelambertianreflection
+	ScatterLambertian ScatterModel = iota
+	// ScatterUniformHemisphere picks all directions above the surface with equal probability,
+	// and weighs them by the cosine instead. It is noisier, but useful as a reference.
+	ScatterUniformHemisphere
+	// ScatterCosineWeighted picks cosine-weighted directions analytically, by projecting a
+	// random point on the unit disk onto the hemisphere (Malley's method).
+	ScatterCosineWeighted
+)
+
 // Matte implements the material interface as a matte or Lambertian material.
 type Matte struct {
 	albedo *utils.Colour
+	// Texture, if provided, gives the albedo at every point instead of the fixed one,
+	// so the surface can show a pattern, like a checkered floor. It uses the UV coordinates of the hit.
+	Texture textures.Texture
+	// Model for picking the directions of the scattered rays. It defaults to ScatterLambertian.
+	Model ScatterModel
 }
 
 // NewMatte returns a new Matte material.
@@ -15,13 +40,81 @@ func NewMatte(albedo *utils.Colour) *Matte {
 	return &Matte{albedo: albedo}
 }
 
-func (m *Matte) Scatter(_ *utils.Ray, hitInfo *RayHit) (*utils.Ray, *utils.Colour, bool) {
-	scatterDir := hitInfo.Normal.Add(random.UnitVec3())
+// NewTexturedMatte returns a new Matte material whose albedo is given by the texture.
+func NewTexturedMatte(texture textures.Texture) *Matte {
+	return &Matte{Texture: texture}
+}
+
+func (m *Matte) Scatter(_ *utils.Ray, hitInfo *RayHit, rng *random.Generator) (*utils.Ray, *utils.Colour, bool) {
+	albedo := m.DiffuseAlbedo(hitInfo)
+
+	switch m.Model {
+	case ScatterUniformHemisphere:
+		return scatterUniform(hitInfo, albedo, rng)
+	case ScatterCosineWeighted:
+		return scatterCosine(hitInfo, albedo, rng)
+	}
+
+	scatterDir := hitInfo.Normal.Add(rng.UnitVec3())
 
 	// Catch degenerate scatter direction.
 	if scatterDir.IsNearZero() {
 		scatterDir = hitInfo.Normal
 	}
 
-	return utils.NewRay(hitInfo.Point, scatterDir), m.albedo, true
+	return utils.NewRay(hitInfo.Point, scatterDir), albedo, true
+}
+
+// scatterUniform scatters the ray in a uniformly random direction above the surface.
+func scatterUniform(hitInfo *RayHit, albedo *utils.Colour, rng *random.Generator) (*utils.Ray, *utils.Colour, bool) {
+	// The cosine of the polar angle is uniform in [0, 1] for a uniform distribution over the hemisphere.
+	cosTheta := rng.Float()
+	scatterDir := hemisphereDir(hitInfo.Normal, cosTheta, rng.FloatBetween(0, 2*math.Pi))
+
+	// The probability density is 1 / 2π while the Lambertian reflectance is cosine / π,
+	// so every ray carries twice the cosine of the albedo.
+	return utils.NewRay(hitInfo.Point, scatterDir), albedo.Scale(2 * cosTheta), true
+}
+
+// scatterCosine scatters the ray in a cosine-weighted random direction above the surface.
+func scatterCosine(hitInfo *RayHit, albedo *utils.Colour, rng *random.Generator) (*utils.Ray, *utils.Colour, bool) {
+	// A uniform point on the unit disk, projected up onto the hemisphere, is cosine-weighted.
+	disk := rng.Vec3InUnitDiskConcentric()
+	cosTheta := math.Sqrt(math.Max(1-disk.X*disk.X-disk.Y*disk.Y, 0))
+	scatterDir := hemisphereDir(hitInfo.Normal, cosTheta, math.Atan2(disk.Y, disk.X))
+
+	// The probability density cancels out the cosine of the reflectance.
+	return utils.NewRay(hitInfo.Point, scatterDir), albedo, true
+}
+
+// hemisphereDir returns the direction with the given polar angle cosine and azimuth,
+// in the hemisphere around the given axis, which is expected to be a unit vector.
+func hemisphereDir(axis *utils.Vec3, cosTheta, phi float64) *utils.Vec3 {
+	axisU, axisV := axis.OrthonormalBasis()
+	sinTheta := math.Sqrt(1 - cosTheta*cosTheta)
+
+	return axis.Mul(cosTheta).
+		Add(axisU.Mul(sinTheta * math.Cos(phi))).
+		Add(axisV.Mul(sinTheta * math.Sin(phi)))
+}
+
+// PDF returns the probability density (with respect to solid angle) with which Scatter picks
+// the given direction at the point-of-hit. It is zero for the directions below the surface.
+func (m *Matte) PDF(hitInfo *RayHit, dir *utils.Vec3) float64 {
+	cosine := hitInfo.Normal.Dot(dir.Dir())
+	if cosine <= 0 {
+		return 0
+	}
+	if m.Model == ScatterUniformHemisphere {
+		return 1 / (2 * math.Pi)
+	}
+	// Both the Lambertian and the cosine-weighted models pick cosine-weighted directions.
+	return cosine / math.Pi
+}
+
+func (m *Matte) DiffuseAlbedo(hitInfo *RayHit) *utils.Colour {
+	if m.Texture != nil {
+		return m.Texture.Value(hitInfo.U, hitInfo.V, hitInfo.Point)
+	}
+	return m.albedo
 }
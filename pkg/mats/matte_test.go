@@ -0,0 +1,54 @@
+package mats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestMatte_ScatterModels(t *testing.T) {
+	hitInfo := &RayHit{Point: utils.NewVec3(0, 0, 0), Normal: utils.NewVec3(0, 1, 0), IsRayOutside: true}
+	ray := utils.NewRay(utils.NewVec3(0, 1, 0), utils.NewVec3(0, -1, 0))
+
+	tests := []struct {
+		name  string
+		model ScatterModel
+		// The fraction of the directions more than 60 degrees away from the normal, where the cosine is below 0.5.
+		// It is 1/2 for the uniform distribution and 1/4 for the cosine-weighted one.
+		wantLow float64
+	}{
+		{name: "lambertian", model: ScatterLambertian, wantLow: 0.25},
+		{name: "uniform hemisphere", model: ScatterUniformHemisphere, wantLow: 0.5},
+		{name: "cosine-weighted", model: ScatterCosineWeighted, wantLow: 0.25},
+	}
+
+	for _, test := range tests {
+		matte := &Matte{albedo: utils.NewColour(0.5, 0.5, 0.5), Model: test.model}
+		rng := random.New(19)
+
+		var low int
+		var reflectance float64
+		const samples = 40000
+		for i := 0; i < samples; i++ {
+			scattered, attenuation, _ := matte.Scatter(ray, hitInfo, rng)
+			cosine := scattered.Dir.Dir().Dot(hitInfo.Normal)
+			if cosine < -1e-9 {
+				t.Fatalf("%s: expected the scattered rays to stay above the surface, got %v", test.name, scattered.Dir)
+			}
+			if cosine < 0.5 {
+				low++
+			}
+			reflectance += attenuation.R
+		}
+
+		if got := float64(low) / samples; math.Abs(got-test.wantLow) > 0.01 {
+			t.Errorf("%s: expected %v of the directions near the grazing angle, got %v", test.name, test.wantLow, got)
+		}
+		// All the models reflect the same light on average.
+		if got := reflectance / samples; math.Abs(got-0.5) > 0.01 {
+			t.Errorf("%s: expected the average reflectance of 0.5, got %v", test.name, got)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package mats
 
 import (
 	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/textures"
 	"github.com/shivanshkc/lightshow/pkg/utils"
 )
 
@@ -12,6 +13,9 @@ type Metallic struct {
 	// To know more, visit-
 	// https://raytracing.github.io/books/RayTracingInOneWeekend.html#metal/fuzzyreflection
 	Fuzz float64
+	// FuzzTexture, if provided, gives the fuzz at every point instead of the fixed one, as the luminance
+	// of its colour, so a single metal can vary from a mirror to brushed. It uses the UV coordinates of the hit.
+	FuzzTexture textures.Texture
 }
 
 // NewMetallic returns a new Metallic material instance.
@@ -19,14 +23,27 @@ func NewMetallic(attn *utils.Colour, fuzz float64) *Metallic {
 	return &Metallic{Attenuation: attn, Fuzz: fuzz}
 }
 
-func (m *Metallic) Scatter(ray *utils.Ray, hitInfo *RayHit) (*utils.Ray, *utils.Colour, bool) {
+// NewTexturedMetallic returns a new Metallic material whose fuzz is given by the luminance of the texture.
+func NewTexturedMetallic(attn *utils.Colour, fuzz textures.Texture) *Metallic {
+	return &Metallic{Attenuation: attn, FuzzTexture: fuzz}
+}
+
+func (m *Metallic) Scatter(ray *utils.Ray, hitInfo *RayHit, rng *random.Generator) (*utils.Ray, *utils.Colour, bool) {
 	// Get the reflection of the ray.
 	reflected := ray.Dir.Reflected(hitInfo.Normal).Dir()
 
 	// To understand why we're using a random vector in unit sphere here, go to-
 	// https://raytracing.github.io/books/RayTracingInOneWeekend.html#metal/fuzzyreflection
-	scatteredDir := reflected.Add(random.Vec3InUnitSphere().Mul(m.Fuzz)).Dir()
+	scatteredDir := reflected.Add(rng.Vec3InUnitSphere().Mul(m.fuzzAt(hitInfo))).Dir()
 	scattered := utils.NewRay(hitInfo.Point, scatteredDir)
 
 	return scattered, m.Attenuation, scatteredDir.Dot(hitInfo.Normal) > 0
 }
+
+// fuzzAt returns the fuzz of the metal at the point-of-hit.
+func (m *Metallic) fuzzAt(hitInfo *RayHit) float64 {
+	if m.FuzzTexture != nil {
+		return m.FuzzTexture.Value(hitInfo.U, hitInfo.V, hitInfo.Point).Luminance()
+	}
+	return m.Fuzz
+}
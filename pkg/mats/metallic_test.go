@@ -0,0 +1,54 @@
+package mats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/textures"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestMetallic_FuzzTexture(t *testing.T) {
+	// Mirror-like even cells and fuzzy odd cells.
+	roughness := textures.NewChecker(utils.NewColour(0, 0, 0), utils.NewColour(0.6, 0.6, 0.6), 4)
+	metal := NewTexturedMetallic(utils.NewColour(0.9, 0.9, 0.9), roughness)
+
+	ray := utils.NewRay(utils.NewVec3(-1, 1, 0), utils.NewVec3(1, -1, 0))
+	mirror := utils.NewVec3(1, 1, 0).Dir()
+	rng := random.New(3)
+
+	// The average angle between the scattered rays and the mirror reflection, at the middle of a cell along U.
+	spread := func(cell float64) float64 {
+		u := (cell + 0.5) / 4
+		hitInfo := &RayHit{
+			Point:        utils.NewVec3(0, 0, 0),
+			Normal:       utils.NewVec3(0, 1, 0),
+			IsRayOutside: true,
+			U:            u,
+			V:            0.1,
+		}
+
+		var sum float64
+		const samples = 2000
+		for i := 0; i < samples; i++ {
+			scattered, _, _ := metal.Scatter(ray, hitInfo, rng)
+			sum += math.Acos(math.Min(scattered.Dir.Dir().Dot(mirror), 1))
+		}
+		return sum / samples
+	}
+
+	for cell := 0.0; cell < 4; cell++ {
+		got := spread(cell)
+		if isEven := int(cell)%2 == 0; isEven && got > 1e-6 {
+			t.Errorf("expected the mirror-like cell %v to reflect sharply, got an average spread of %v", cell, got)
+		} else if !isEven && got < 0.1 {
+			t.Errorf("expected the fuzzy cell %v to blur the reflections, got an average spread of %v", cell, got)
+		}
+	}
+
+	// The scalar fuzz still works without a texture.
+	if got := NewMetallic(utils.NewColour(1, 1, 1), 0.6).fuzzAt(&RayHit{U: 0.1}); got != 0.6 {
+		t.Errorf("expected the scalar fuzz of 0.6, got %v", got)
+	}
+}
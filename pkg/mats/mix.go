@@ -0,0 +1,36 @@
+package mats
+
+import (
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// Mix implements the material interface as a probabilistic blend of two materials.
+//
+// For every ray, one of the two materials is chosen to scatter it. Over many samples,
+// this blends their appearances. It is useful for surfaces like scratched paint.
+type Mix struct {
+	A, B Material
+	// Factor is the probability of choosing material A. It should lie in the [0, 1] interval.
+	Factor float64
+}
+
+// NewMix returns a new Mix material that chooses material "a" with the
+// probability "factor" and material "b" otherwise.
+func NewMix(a, b Material, factor float64) *Mix {
+	return &Mix{A: a, B: b, Factor: factor}
+}
+
+func (m *Mix) Scatter(ray *utils.Ray, hitInfo *RayHit, rng *random.Generator) (*utils.Ray, *utils.Colour, bool) {
+	scattered, attenuation, _, isScattered := m.ScatterLobe(ray, hitInfo, rng)
+	return scattered, attenuation, isScattered
+}
+
+// ScatterLobe is like Scatter. The ray gets the lobe that the chosen material scatters it by.
+func (m *Mix) ScatterLobe(ray *utils.Ray, hitInfo *RayHit, rng *random.Generator,
+) (*utils.Ray, *utils.Colour, Lobe, bool) {
+	if rng.Float() < m.Factor {
+		return ScatterWithLobe(m.A, ray, hitInfo, rng)
+	}
+	return ScatterWithLobe(m.B, ray, hitInfo, rng)
+}
@@ -0,0 +1,46 @@
+package mats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestMix_Reflectivity(t *testing.T) {
+	mirror := NewMetallic(utils.NewColour(1, 1, 1), 0)
+	matte := NewMatte(utils.NewColour(1, 1, 1))
+
+	ray := utils.NewRay(utils.NewVec3(-1, 1, 0), utils.NewVec3(1, -1, 0))
+	reflected := utils.NewVec3(1, 1, 0).Dir()
+	hitInfo := &RayHit{Point: utils.NewVec3(0, 0, 0), Normal: utils.NewVec3(0, 1, 0), IsRayOutside: true}
+
+	// The fraction of the scattered rays that leave along the mirror reflection.
+	reflectivity := func(mat Material) float64 {
+		rng := random.New(5)
+
+		var mirrored int
+		const samples = 10000
+		for i := 0; i < samples; i++ {
+			scattered, _, _ := mat.Scatter(ray, hitInfo, rng)
+			if scattered.Dir.Dir().Dot(reflected) > 1-1e-9 {
+				mirrored++
+			}
+		}
+		return float64(mirrored) / samples
+	}
+
+	if got := reflectivity(mirror); got != 1 {
+		t.Errorf("expected the mirror to reflect every ray, got a reflectivity of %v", got)
+	}
+	if got := reflectivity(matte); got != 0 {
+		t.Errorf("expected the matte to reflect no ray like a mirror, got a reflectivity of %v", got)
+	}
+	if got := reflectivity(NewMix(mirror, matte, 0.5)); math.Abs(got-0.5) > 0.02 {
+		t.Errorf("expected the 50/50 mix to reflect about half the rays like a mirror, got a reflectivity of %v", got)
+	}
+	if got := reflectivity(NewMix(mirror, matte, 0.2)); math.Abs(got-0.2) > 0.02 {
+		t.Errorf("expected the 20/80 mix to reflect about a fifth of the rays like a mirror, got a reflectivity of %v", got)
+	}
+}
@@ -0,0 +1,84 @@
+package mats
+
+import (
+	"math"
+
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// OrenNayar implements the material interface as a rough diffuse surface,
+// using the Oren–Nayar reflectance model.
+//
+// Compared to Matte (which is Lambertian), it looks flatter and brightens at grazing angles,
+// which suits materials like clay, concrete or the moon.
+//
+// To know more, visit-
+// https://en.wikipedia.org/wiki/Oren%E2%80%93Nayar_reflectance_model
+type OrenNayar struct {
+	Albedo *utils.Colour
+	// Sigma is the roughness of the surface, as the standard deviation (in radians)
+	// of the microfacet orientation angle. A zero sigma is equivalent to Matte.
+	Sigma float64
+}
+
+// NewOrenNayar returns a new OrenNayar material.
+func NewOrenNayar(albedo *utils.Colour, sigma float64) *OrenNayar {
+	return &OrenNayar{Albedo: albedo, Sigma: sigma}
+}
+
+func (o *OrenNayar) Scatter(ray *utils.Ray, hitInfo *RayHit, rng *random.Generator) (*utils.Ray, *utils.Colour, bool) {
+	scattered, attenuation, _, isScattered := o.ScatterLobe(ray, hitInfo, rng)
+	return scattered, attenuation, isScattered
+}
+
+// ScatterLobe is like Scatter. The rays are always scattered diffusely.
+func (o *OrenNayar) ScatterLobe(ray *utils.Ray, hitInfo *RayHit, rng *random.Generator,
+) (*utils.Ray, *utils.Colour, Lobe, bool) {
+	// The scatter direction is sampled exactly like the Matte material.
+	// Since that sampling is cosine-weighted, the Lambertian term cancels out and only
+	// the Oren–Nayar factor remains to be applied on the albedo.
+	scatterDir := hitInfo.Normal.Add(rng.UnitVec3())
+
+	// Catch degenerate scatter direction.
+	if scatterDir.IsNearZero() {
+		scatterDir = hitInfo.Normal
+	}
+
+	scattered := utils.NewRay(hitInfo.Point, scatterDir)
+	factor := o.factor(ray.Dir.Mul(-1), scattered.Dir, hitInfo.Normal)
+
+	return scattered, o.Albedo.Scale(factor), LobeDiffuse, true
+}
+
+// factor calculates the Oren–Nayar multiplier for the given outgoing (toward the viewer)
+// and incoming (toward the light) directions.
+func (o *OrenNayar) factor(out, in, normal *utils.Vec3) float64 {
+	sigmaSq := o.Sigma * o.Sigma
+	a := 1 - 0.5*sigmaSq/(sigmaSq+0.33)
+	b := 0.45 * sigmaSq / (sigmaSq + 0.09)
+
+	cosIn := math.Max(math.Min(in.Dot(normal), 1), 0)
+	cosOut := math.Max(math.Min(out.Dot(normal), 1), 0)
+	sinIn := math.Sqrt(1 - cosIn*cosIn)
+	sinOut := math.Sqrt(1 - cosOut*cosOut)
+
+	// Cosine of the azimuthal angle between the two directions,
+	// calculated by projecting them onto the tangent plane.
+	inTangent := in.Sub(normal.Mul(cosIn))
+	outTangent := out.Sub(normal.Mul(cosOut))
+	cosPhi := 0.0
+	if magProduct := inTangent.Mag() * outTangent.Mag(); magProduct > 1e-8 {
+		cosPhi = math.Max(inTangent.Dot(outTangent)/magProduct, 0)
+	}
+
+	// Alpha is the bigger of the two polar angles and beta is the smaller one.
+	var sinAlpha, tanBeta float64
+	if cosIn < cosOut {
+		sinAlpha, tanBeta = sinIn, sinOut/math.Max(cosOut, 1e-8)
+	} else {
+		sinAlpha, tanBeta = sinOut, sinIn/math.Max(cosIn, 1e-8)
+	}
+
+	return a + b*cosPhi*sinAlpha*tanBeta
+}
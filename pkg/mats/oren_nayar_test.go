@@ -0,0 +1,46 @@
+package mats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestOrenNayar_Grazing(t *testing.T) {
+	normal := utils.NewVec3(0, 1, 0)
+	// The direction at the given angle from the normal, in the XY plane, on the given side of it.
+	at := func(degrees, side float64) *utils.Vec3 {
+		radians := degrees * math.Pi / 180
+		return utils.NewVec3(side*math.Sin(radians), math.Cos(radians), 0)
+	}
+
+	// A zero roughness is the Lambertian reflection, whose factor is one for every pair of directions.
+	lambertian := NewOrenNayar(utils.NewColour(1, 1, 1), 0)
+	for _, degrees := range []float64{0, 45, 80, 89} {
+		if got := lambertian.factor(at(degrees, 1), at(degrees, 1), normal); math.Abs(got-1) > 1e-12 {
+			t.Errorf("expected the Lambertian factor of 1 at %v degrees, got %v", degrees, got)
+		}
+	}
+
+	rough := NewOrenNayar(utils.NewColour(1, 1, 1), 0.5)
+
+	// Seen from near the light, the rough surface gets brighter towards grazing angles (retro-reflection).
+	var previous float64
+	for _, degrees := range []float64{0, 30, 60, 80} {
+		got := rough.factor(at(degrees, 1), at(degrees, 1), normal)
+		if got < previous {
+			t.Errorf("expected the retro-reflection to grow towards grazing angles, got %v at %v degrees after %v",
+				got, degrees, previous)
+		}
+		previous = got
+	}
+	if previous <= 1 {
+		t.Errorf("expected the grazing retro-reflection to beat the Lambertian one, got a factor of %v", previous)
+	}
+
+	// Seen from the opposite side of the light, it is darker than a Lambertian surface.
+	if got := rough.factor(at(80, 1), at(80, -1), normal); got >= 1 {
+		t.Errorf("expected the grazing forward-scattering to be darker than the Lambertian one, got a factor of %v", got)
+	}
+}
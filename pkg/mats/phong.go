@@ -0,0 +1,63 @@
+package mats
+
+import (
+	"math"
+
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// Phong implements the material interface as a glossy, plastic-like surface, using the
+// cosine-power (Phong) distribution for its specular highlights. It is cheaper than microfacet models.
+//
+// For every ray, the Specular fraction decides whether the ray is reflected around the mirror
+// direction or scattered diffusely like a Matte surface.
+//
+// To know more, visit-
+// https://en.wikipedia.org/wiki/Phong_reflection_model
+type Phong struct {
+	// Albedo is the colour of the diffuse part of the surface.
+	Albedo *utils.Colour
+	// Specular is the fraction of the rays, in the [0, 1] interval, that are reflected glossily.
+	Specular float64
+	// Exponent of the cosine-power distribution. Higher values give sharper, mirror-like highlights.
+	// For reference, 10 looks like rough plastic and 1000 looks like polished plastic.
+	Exponent float64
+}
+
+// NewPhong returns a new Phong material.
+func NewPhong(albedo *utils.Colour, specular, exponent float64) *Phong {
+	return &Phong{Albedo: albedo, Specular: specular, Exponent: exponent}
+}
+
+func (p *Phong) Scatter(ray *utils.Ray, hitInfo *RayHit, rng *random.Generator) (*utils.Ray, *utils.Colour, bool) {
+	scattered, attenuation, _, isScattered := p.ScatterLobe(ray, hitInfo, rng)
+	return scattered, attenuation, isScattered
+}
+
+// ScatterLobe is like Scatter. The diffuse bounces and the specular highlights have their own lobes.
+func (p *Phong) ScatterLobe(ray *utils.Ray, hitInfo *RayHit, rng *random.Generator,
+) (*utils.Ray, *utils.Colour, Lobe, bool) {
+	// Diffuse bounce, exactly like the Matte material.
+	if p.Specular <= rng.Float() {
+		scatterDir := hitInfo.Normal.Add(rng.UnitVec3())
+		if scatterDir.IsNearZero() {
+			scatterDir = hitInfo.Normal
+		}
+		return utils.NewRay(hitInfo.Point, scatterDir), p.Albedo, LobeDiffuse, true
+	}
+
+	// Sample the specular lobe, where the density of directions is proportional to the
+	// cosine of their angle with the mirror direction, raised to the exponent.
+	reflected := ray.Dir.Reflected(hitInfo.Normal).Dir()
+	cosTheta := math.Pow(rng.Float(), 1/(p.Exponent+1))
+	scatteredDir := hemisphereDir(reflected, cosTheta, rng.FloatBetween(0, 2*math.Pi))
+
+	// Directions that end up below the surface are absorbed.
+	scattered := utils.NewRay(hitInfo.Point, scatteredDir)
+	return scattered, utils.NewColour(1, 1, 1), LobeSpecular, scatteredDir.Dot(hitInfo.Normal) > 0
+}
+
+func (p *Phong) DiffuseAlbedo(*RayHit) *utils.Colour {
+	return p.Albedo.Scale(1 - p.Specular)
+}
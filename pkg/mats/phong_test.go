@@ -0,0 +1,44 @@
+package mats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestPhong_Exponent(t *testing.T) {
+	hitInfo := &RayHit{Point: utils.NewVec3(0, 0, 0), Normal: utils.NewVec3(0, 1, 0), IsRayOutside: true}
+	ray := utils.NewRay(utils.NewVec3(0, 1, 0), utils.NewVec3(0, -1, 0))
+	reflected := utils.NewVec3(0, 1, 0)
+
+	// The average cosine of the angle between the specular scatters and the mirror reflection.
+	concentration := func(exponent float64) float64 {
+		phong := NewPhong(utils.NewColour(1, 1, 1), 1, exponent)
+		rng := random.New(17)
+
+		var sum float64
+		const samples = 20000
+		for i := 0; i < samples; i++ {
+			scattered, _, _ := phong.Scatter(ray, hitInfo, rng)
+			sum += scattered.Dir.Dir().Dot(reflected)
+		}
+		return sum / samples
+	}
+
+	previous := 0.0
+	for _, exponent := range []float64{1, 10, 100, 1000} {
+		got := concentration(exponent)
+		if got <= previous {
+			t.Errorf("expected the exponent %v to concentrate the reflections more, got %v after %v",
+				exponent, got, previous)
+		}
+		previous = got
+
+		// The cosines follow the cosⁿ distribution, whose mean is (n+1) / (n+2).
+		if want := (exponent + 1) / (exponent + 2); math.Abs(got-want) > 0.01 {
+			t.Errorf("expected the average cosine %v for the exponent %v, got %v", want, exponent, got)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package mats
+
+import (
+	"math"
+
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// Spotlight implements the material interface as a light source that emits only within
+// a cone around its direction. It does not scatter any rays.
+//
+// The emission is at full intensity within the inner cone, falls off smoothly between the
+// inner and outer cones and is zero outside the outer cone.
+type Spotlight struct {
+	// Colour of the emitted light.
+	Colour *utils.Colour
+	// Intensity multiplies the colour of the emitted light.
+	Intensity float64
+
+	// Direction in which the spotlight points.
+	Direction *utils.Vec3
+	// InnerAngle is the half-angle in degrees of the cone of full intensity.
+	InnerAngle float64
+	// OuterAngle is the half-angle in degrees of the cone beyond which there is no emission.
+	OuterAngle float64
+}
+
+// NewSpotlight returns a new Spotlight material instance.
+func NewSpotlight(col *utils.Colour, intensity float64, dir *utils.Vec3, innerAngle, outerAngle float64,
+) *Spotlight {
+	return &Spotlight{
+		Colour: col, Intensity: intensity,
+		Direction: dir, InnerAngle: innerAngle, OuterAngle: outerAngle,
+	}
+}
+
+func (s *Spotlight) Scatter(*utils.Ray, *RayHit, *random.Generator) (*utils.Ray, *utils.Colour, bool) {
+	return nil, nil, false
+}
+
+func (s *Spotlight) Emit(ray *utils.Ray, _ *RayHit) *utils.Colour {
+	// The light travels opposite to the ray.
+	cosine := ray.Dir.Mul(-1).Dot(s.Direction.Dir())
+	return s.Colour.Scale(s.Intensity * s.falloff(cosine))
+}
+
+// falloff returns the fraction of the full intensity that is emitted at the angle
+// (from the spotlight direction) whose cosine is given.
+func (s *Spotlight) falloff(cosine float64) float64 {
+	cosInner := math.Cos(s.InnerAngle * math.Pi / 180)
+	cosOuter := math.Cos(s.OuterAngle * math.Pi / 180)
+
+	switch {
+	case cosine >= cosInner:
+		return 1
+	case cosine <= cosOuter:
+		return 0
+	}
+
+	// Smoothstep between the outer and the inner cone.
+	x := (cosine - cosOuter) / (cosInner - cosOuter)
+	return x * x * (3 - 2*x)
+}
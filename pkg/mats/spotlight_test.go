@@ -0,0 +1,50 @@
+package mats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestSpotlight_Cones(t *testing.T) {
+	// A spotlight pointing down, with full intensity up to 20 degrees and none beyond 40 degrees.
+	spot := NewSpotlight(utils.NewColour(1, 0.5, 0.25), 4, utils.NewVec3(0, -1, 0), 20, 40)
+
+	// The emission towards the eye that sees the light from the given angle off its direction.
+	emission := func(degrees float64) *utils.Colour {
+		radians := degrees * math.Pi / 180
+		// The ray travels from the eye below the light, to the light.
+		eyeToLight := utils.NewVec3(-math.Sin(radians), math.Cos(radians), 0)
+		return spot.Emit(utils.NewRay(utils.NewVec3(0, 0, 0), eyeToLight), nil)
+	}
+
+	full := utils.NewColour(4, 2, 1)
+	for _, degrees := range []float64{0, 10, 19.9} {
+		if got := emission(degrees); !got.ApproxEqual(full, 1e-9) {
+			t.Errorf("expected the full emission %v inside the inner cone at %v degrees, got %v", full, degrees, got)
+		}
+	}
+	for _, degrees := range []float64{40.1, 60, 90, 180} {
+		if got := emission(degrees); !got.ApproxEqual(utils.NewColour(0, 0, 0), 1e-9) {
+			t.Errorf("expected no emission outside the outer cone at %v degrees, got %v", degrees, got)
+		}
+	}
+
+	// Between the cones, the emission falls off monotonically, keeping the colour.
+	previous := emission(20)
+	for degrees := 21.0; degrees <= 40; degrees++ {
+		got := emission(degrees)
+		if got.R > previous.R {
+			t.Errorf("expected the emission to fall off between the cones, got %v at %v degrees after %v",
+				got, degrees, previous)
+		}
+		if got.R > 0 && math.Abs(got.G/got.R-0.5) > 1e-9 {
+			t.Errorf("expected the falloff to keep the colour, got %v at %v degrees", got, degrees)
+		}
+		previous = got
+	}
+	if got := emission(30); got.R <= 0 || got.R >= full.R {
+		t.Errorf("expected a partial emission halfway between the cones, got %v", got)
+	}
+}
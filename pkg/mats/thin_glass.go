@@ -0,0 +1,50 @@
+package mats
+
+import (
+	"math"
+
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// ThinGlass implements the material interface as a thin dielectric slab, like a window pane
+// or a soap bubble.
+//
+// Unlike Glass, which models a solid volume, it does not bend the transmitted rays.
+// They continue in the same direction, so objects behind it are not inverted or distorted.
+type ThinGlass struct {
+	// RefractiveIndex of the material. It only affects the amount of reflection.
+	RefractiveIndex float64
+	// Tint is the attenuation of the transmitted rays. It is white if nil.
+	Tint *utils.Colour
+}
+
+// NewThinGlass returns a new ThinGlass material instance.
+func NewThinGlass(ri float64, tint *utils.Colour) *ThinGlass {
+	return &ThinGlass{RefractiveIndex: ri, Tint: tint}
+}
+
+func (t *ThinGlass) Scatter(ray *utils.Ray, hitInfo *RayHit, rng *random.Generator) (*utils.Ray, *utils.Colour, bool) {
+	// Safely calculating the cosine of the angle of incidence.
+	cosine := math.Min(ray.Dir.Mul(-1).Dot(hitInfo.Normal), 1)
+
+	// The slab has the same medium on both sides, so the ray always enters from outside.
+	// The reflectance accounts for both interfaces of the slab, and the light bouncing between them.
+	// To know more, visit-
+	// https://pbr-book.org/4ed/Reflection_Models/Dielectric_BSDF#ThinDielectricBSDF
+	reflectance := fresnel(cosine, 1/t.RefractiveIndex)
+	if reflectance < 1 {
+		reflectance += (1 - reflectance) * (1 - reflectance) * reflectance / (1 - reflectance*reflectance)
+	}
+
+	if reflectance > rng.Float() {
+		return utils.NewRay(hitInfo.Point, ray.Dir.Reflected(hitInfo.Normal)), utils.NewColour(1, 1, 1), true
+	}
+
+	tint := t.Tint
+	if tint == nil {
+		tint = utils.NewColour(1, 1, 1)
+	}
+
+	return utils.NewRay(hitInfo.Point, ray.Dir), tint, true
+}
@@ -0,0 +1,49 @@
+package mats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestThinGlass_NoInversion(t *testing.T) {
+	hitInfo := &RayHit{Point: utils.NewVec3(0, 0, 0), Normal: utils.NewVec3(0, 1, 0), IsRayOutside: true}
+	tint := utils.NewColour(0.9, 1, 0.9)
+	rng := random.New(11)
+
+	for _, degrees := range []float64{0, 30, 60} {
+		ray := rayAt(degrees)
+		mirror := ray.Dir.Reflected(hitInfo.Normal).Dir()
+
+		// The thin glass lets the rays through without bending them, so the scene behind it is not inverted,
+		// unlike behind a solid glass ball, which works like a lens.
+		var transmitted int
+		for i := 0; i < 1000; i++ {
+			scattered, attenuation, _ := NewThinGlass(1.5, tint).Scatter(ray, hitInfo, rng)
+			switch dir := scattered.Dir.Dir(); {
+			case dir.Dot(ray.Dir.Dir()) > 1-1e-9:
+				transmitted++
+				if !attenuation.ApproxEqual(tint, 1e-9) {
+					t.Errorf("expected the transmitted rays to be tinted %v, got %v", tint, attenuation)
+				}
+			case dir.Dot(mirror) < 1-1e-9:
+				t.Fatalf("expected the ray at %v degrees to pass straight or reflect, got the direction %v", degrees, dir)
+			}
+		}
+		if transmitted < 800 {
+			t.Errorf("expected most of the rays at %v degrees to pass through, got %d of 1000", degrees, transmitted)
+		}
+
+		// The solid glass bends the refracted rays towards the normal.
+		_, refracted, _, _ := NewGlass(1.5).Split(ray, hitInfo)
+		bent := math.Acos(-refracted.Dir.Dir().Y) * 180 / math.Pi
+		if want := math.Asin(math.Sin(degrees*math.Pi/180)/1.5) * 180 / math.Pi; math.Abs(bent-want) > 1e-6 {
+			t.Errorf("expected the solid glass to bend the ray at %v degrees to %v degrees, got %v", degrees, want, bent)
+		}
+		if degrees > 0 && math.Abs(bent-degrees) < 1 {
+			t.Errorf("expected the solid glass to bend the ray at %v degrees, got %v degrees", degrees, bent)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package random
+
+// Generator is a pseudo-random number generator based on the xoshiro256** algorithm.
+//
+// It is not safe for concurrent use. Every goroutine should use its own Generator,
+// which also makes the results reproducible for a given seed.
+type Generator struct {
+	state [4]uint64
+}
+
+// New returns a new Generator for the given seed.
+func New(seed uint64) *Generator {
+	gen := &Generator{}
+	// The state is initialized using the SplitMix64 sequence, as recommended by the authors.
+	for i := range gen.state {
+		gen.state[i] = splitmix64(seed + uint64(i)*0x9E3779B97F4A7C15)
+	}
+
+	return gen
+}
+
+// Float generates a random float in the [0, 1) interval.
+func (g *Generator) Float() float64 {
+	// The top 53 bits fill the mantissa of a float64.
+	return float64(g.next()>>11) / (1 << 53)
+}
+
+// FloatBetween generates a random float between the given min and max range.
+func (g *Generator) FloatBetween(min, max float64) float64 {
+	return min + (g.Float() * (max - min))
+}
+
+// next advances the state and returns the next random 64-bit value.
+//
+// To know more, visit-
+// https://prng.di.unimi.it/xoshiro256starstar.c
+func (g *Generator) next() uint64 {
+	state := &g.state
+	result := rotl64(state[1]*5, 7) * 9
+	shifted := state[1] << 17
+
+	state[2] ^= state[0]
+	state[3] ^= state[1]
+	state[1] ^= state[2]
+	state[0] ^= state[3]
+	state[2] ^= shifted
+	state[3] = rotl64(state[3], 45)
+
+	return result
+}
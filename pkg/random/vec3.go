@@ -1,47 +1,124 @@
 package random
 
 import (
+	"math"
+
 	"github.com/shivanshkc/lightshow/pkg/utils"
 )
 
 // Vec3 generates a random Vec3 whose all components lie between [0, 1).
+// Like Float, it uses the global Generator.
 func Vec3() *utils.Vec3 {
-	return utils.NewVec3(Float(), Float(), Float())
+	global.Lock()
+	defer global.Unlock()
+
+	return global.gen.Vec3()
 }
 
 // Vec3Between generates a random Vec3 whose all components lie between
-// the given min and max range.
+// the given min and max range. Like Float, it uses the global Generator.
 func Vec3Between(min, max float64) *utils.Vec3 {
+	global.Lock()
+	defer global.Unlock()
+
+	return global.gen.Vec3Between(min, max)
+}
+
+// UnitVec3 returns a random unit Vec3. Like Float, it uses the global Generator.
+func UnitVec3() *utils.Vec3 {
+	global.Lock()
+	defer global.Unlock()
+
+	return global.gen.UnitVec3()
+}
+
+// Vec3InUnitSphere returns a random Vec3 inside a unit sphere. Like Float, it uses the global Generator.
+func Vec3InUnitSphere() *utils.Vec3 {
+	global.Lock()
+	defer global.Unlock()
+
+	return global.gen.Vec3InUnitSphere()
+}
+
+// Vec3InUnitDisk returns a random Vec3 inside a unit disk. Like Float, it uses the global Generator.
+func Vec3InUnitDisk() *utils.Vec3 {
+	global.Lock()
+	defer global.Unlock()
+
+	return global.gen.Vec3InUnitDisk()
+}
+
+// Vec3 generates a random Vec3 whose all components lie between [0, 1).
+func (g *Generator) Vec3() *utils.Vec3 {
+	return utils.NewVec3(g.Float(), g.Float(), g.Float())
+}
+
+// Vec3Between generates a random Vec3 whose all components lie between
+// the given min and max range.
+func (g *Generator) Vec3Between(min, max float64) *utils.Vec3 {
 	return utils.NewVec3(
-		FloatBetween(min, max),
-		FloatBetween(min, max),
-		FloatBetween(min, max),
+		g.FloatBetween(min, max),
+		g.FloatBetween(min, max),
+		g.FloatBetween(min, max),
 	)
 }
 
 // UnitVec3 returns a random unit Vec3.
-func UnitVec3() *utils.Vec3 {
-	return Vec3().Dir()
+func (g *Generator) UnitVec3() *utils.Vec3 {
+	x, y, z := g.inUnitSphere()
+	// The point is normalized in place, to allocate only once.
+	mag := math.Sqrt(x*x + y*y + z*z)
+	return utils.NewVec3(x/mag, y/mag, z/mag)
 }
 
 // Vec3InUnitSphere returns a random Vec3 inside a unit sphere.
-func Vec3InUnitSphere() *utils.Vec3 {
+func (g *Generator) Vec3InUnitSphere() *utils.Vec3 {
+	return utils.NewVec3(g.inUnitSphere())
+}
+
+// inUnitSphere returns the components of a random point inside a unit sphere.
+// It works on plain floats, so the rejected points are not allocated.
+func (g *Generator) inUnitSphere() (x, y, z float64) {
 	// TODO: Is there a better way than this semi-brute-force?
 	for {
-		point := Vec3Between(-1, 1)
-		if point.DotSelf() < 1 {
-			return point
+		x, y, z = g.FloatBetween(-1, 1), g.FloatBetween(-1, 1), g.FloatBetween(-1, 1)
+		// The zero point has no direction, so it is rejected too.
+		if lengthSq := x*x + y*y + z*z; lengthSq < 1 && lengthSq > 0 {
+			return x, y, z
 		}
 	}
 }
 
 // Vec3InUnitDisk returns a random Vec3 inside a unit disk.
-func Vec3InUnitDisk() *utils.Vec3 {
+func (g *Generator) Vec3InUnitDisk() *utils.Vec3 {
 	// TODO: Is there a better way than this semi-brute-force?
 	for {
-		vec := utils.NewVec3(FloatBetween(-1, 1), FloatBetween(-1, 1), 0)
-		if vec.DotSelf() < 1 {
-			return vec
+		x, y := g.FloatBetween(-1, 1), g.FloatBetween(-1, 1)
+		if x*x+y*y < 1 {
+			return utils.NewVec3(x, y, 0)
 		}
 	}
 }
+
+// Vec3InUnitDiskConcentric returns a uniformly distributed random Vec3 inside a unit disk.
+//
+// Unlike Vec3InUnitDisk, it does not reject any samples. It uses Shirley's concentric mapping,
+// which maps a square to a disk with low distortion. To know more, visit-
+// https://pbr-book.org/3ed-2018/Monte_Carlo_Integration/2D_Sampling_with_Multidimensional_Transformations#SamplingaUnitDisk
+func (g *Generator) Vec3InUnitDiskConcentric() *utils.Vec3 {
+	// A random point in the [-1, 1] square.
+	x, y := g.FloatBetween(-1, 1), g.FloatBetween(-1, 1)
+	if x == 0 && y == 0 {
+		return utils.NewVec3(0, 0, 0)
+	}
+
+	// Map the concentric squares to concentric circles, one triangular wedge at a time.
+	var radius, theta float64
+	if math.Abs(x) > math.Abs(y) {
+		radius, theta = x, (math.Pi/4)*(y/x)
+	} else {
+		radius, theta = y, math.Pi/2-(math.Pi/4)*(x/y)
+	}
+
+	return utils.NewVec3(radius*math.Cos(theta), radius*math.Sin(theta), 0)
+}
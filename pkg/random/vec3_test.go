@@ -0,0 +1,34 @@
+package random
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGenerator_Vec3InUnitDiskConcentric(t *testing.T) {
+	rng := New(29)
+
+	// The disk is split into 4 rings of equal area and 8 sectors, which get equal shares of a uniform distribution.
+	const rings, sectors, samples = 4, 8, 80000
+	var counts [rings][sectors]int
+	for i := 0; i < samples; i++ {
+		point := rng.Vec3InUnitDiskConcentric()
+		radiusSq := point.X*point.X + point.Y*point.Y
+		if radiusSq > 1+1e-12 || point.Z != 0 {
+			t.Fatalf("expected the point %v to lie in the unit disk", point)
+		}
+
+		ring := int(math.Min(radiusSq*rings, rings-1))
+		sector := int(math.Min((math.Atan2(point.Y, point.X)+math.Pi)/(2*math.Pi)*sectors, sectors-1))
+		counts[ring][sector]++
+	}
+
+	const want = float64(samples) / (rings * sectors)
+	for ring := range counts {
+		for sector, count := range counts[ring] {
+			if math.Abs(float64(count)-want) > 0.1*want {
+				t.Errorf("expected about %v points in the ring %d and the sector %d, got %d", want, ring, sector, count)
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package renderer
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// renderIDPixel determines the object-ID colour of the given pixel.
+//
+// A single ray is cast through the center of the pixel (and the lens), so the result
+// is stable regardless of sampling. Pixels that do not hit anything are black.
+func (r *Renderer) renderIDPixel(x, y float64, world shape) *utils.Colour {
+	// Bring x and y in the [0, 1) interval, with the ray going through the pixel center.
+	x = (x + 0.5) / (r.opts.ImageWidth - 1)
+	y = (y + 0.5) / (r.opts.ImageHeight - 1)
+
+	r.rays.Add(1)
+	hitInfo, isHit := world.Hit(r.opts.Camera.CastCenterRay(x, y), r.hitInterval())
+	if !isHit {
+		return utils.NewColour(0, 0, 0)
+	}
+
+	return idColour(hitInfo.ID)
+}
+
+// idColour returns a deterministic colour for the given ID.
+// Different IDs are very likely to get visually distinct colours.
+func idColour(id int) *utils.Colour {
+	// Scramble the bits of the ID, so that consecutive IDs get unrelated colours.
+	// This is the finalizer of the SplitMix64 algorithm.
+	hash := uint64(id) + 0x9E3779B97F4A7C15
+	hash = (hash ^ (hash >> 30)) * 0xBF58476D1CE4E5B9
+	hash = (hash ^ (hash >> 27)) * 0x94D049BB133111EB
+	hash ^= hash >> 31
+
+	return utils.NewColour(
+		float64(hash&0xff)/255,
+		float64((hash>>8)&0xff)/255,
+		float64((hash>>16)&0xff)/255,
+	)
+}
+
+// auxiliaryFile returns the path of an auxiliary output (like a render pass) named after the
+// output file. For example, the output file "./dist/image.jpg" and the suffix "id" give "./dist/image-id.png".
+func (r *Renderer) auxiliaryFile(suffix string) string {
+	base := strings.TrimSuffix(r.opts.OutputFile, filepath.Ext(r.opts.OutputFile))
+	return base + "-" + suffix + ".png"
+}
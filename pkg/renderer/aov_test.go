@@ -0,0 +1,54 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/shapes"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestRenderer_IDPass(t *testing.T) {
+	left := shapes.NewSphere(utils.NewVec3(-0.6, 0, -1), 0.5, mats.NewMatte(utils.NewColour(0.8, 0.2, 0.2)))
+	right := shapes.NewSphere(utils.NewVec3(0.6, 0, -1), 0.5, mats.NewGlass(1.5))
+	left.ID, right.ID = 1, 2
+	world := shapes.NewGroup(left, right)
+
+	opts := straightOptions()
+	opts.IDPass = true
+	_, idPixels, _ := New(opts).renderPasses(world)
+
+	leftColour, rightColour, black := idColour(1), idColour(2), utils.NewColour(0, 0, 0)
+	if leftColour.ApproxEqual(rightColour, 0.05) {
+		t.Fatalf("expected the IDs to get distinct colours, got %v and %v", leftColour, rightColour)
+	}
+
+	// Every pixel is one of the two constant colours of the spheres, whatever their materials, or the black of the sky.
+	counts := map[*utils.Colour]int{}
+	for idx, colour := range idPixels {
+		matched := false
+		for _, want := range []*utils.Colour{leftColour, rightColour, black} {
+			if colour.ApproxEqual(want, 0) {
+				counts[want]++
+				matched = true
+			}
+		}
+		if !matched {
+			t.Fatalf("pixel %d: expected the colour of an ID or black, got %v", idx, colour)
+		}
+	}
+	for _, colour := range []*utils.Colour{leftColour, rightColour, black} {
+		if counts[colour] == 0 {
+			t.Errorf("expected some pixels of the colour %v", colour)
+		}
+	}
+
+	// The centers of the spheres are on the middle row, at about 30% and 70% of the width.
+	width, middle := int(opts.ImageWidth), int(opts.ImageHeight)/2
+	if got := idPixels[middle*width+width*3/10]; !got.ApproxEqual(leftColour, 0) {
+		t.Errorf("expected the left sphere to have the colour %v, got %v", leftColour, got)
+	}
+	if got := idPixels[middle*width+width*7/10]; !got.ApproxEqual(rightColour, 0) {
+		t.Errorf("expected the right sphere to have the colour %v, got %v", rightColour, got)
+	}
+}
@@ -0,0 +1,55 @@
+package renderer
+
+import (
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// Background determines the colour of the rays that do not hit any shape.
+type Background interface {
+	// Colour returns the background colour for the given ray direction.
+	// The direction is expected to be a unit vector.
+	Colour(dir *utils.Vec3) *utils.Colour
+}
+
+// SolidBackground is a background of a single colour, regardless of the ray direction.
+type SolidBackground struct {
+	Col *utils.Colour
+}
+
+// NewSolidBackground returns a new SolidBackground of the given colour.
+func NewSolidBackground(col *utils.Colour) *SolidBackground {
+	return &SolidBackground{Col: col}
+}
+
+func (s *SolidBackground) Colour(*utils.Vec3) *utils.Colour {
+	return s.Col
+}
+
+// GradientBackground is a vertical gradient between two colours.
+type GradientBackground struct {
+	// Top is the colour of the rays pointing straight up.
+	Top *utils.Colour
+	// Bottom is the colour of the rays pointing straight down.
+	Bottom *utils.Colour
+}
+
+// NewGradientBackground returns a new GradientBackground between the given colours.
+func NewGradientBackground(top, bottom *utils.Colour) *GradientBackground {
+	return &GradientBackground{Top: top, Bottom: bottom}
+}
+
+func (g *GradientBackground) Colour(dir *utils.Vec3) *utils.Colour {
+	// The {0.5 + (x + 1)} formula converts the [-1, 1] interval to [0, 1]
+	intensity := 0.5 * (dir.Y + 1)
+	return g.Bottom.Lerp(g.Top, intensity)
+}
+
+// SkyFunc is an adapter that allows the use of an ordinary function as a Background.
+// It makes it easy to implement custom sky models, like physically based ones, in Go.
+//
+// The function is called for every ray that does not hit anything, with the ray's direction as a unit vector.
+type SkyFunc func(dir *utils.Vec3) *utils.Colour
+
+func (s SkyFunc) Colour(dir *utils.Vec3) *utils.Colour {
+	return s(dir)
+}
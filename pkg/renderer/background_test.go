@@ -0,0 +1,81 @@
+package renderer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/shapes"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestSolidBackground(t *testing.T) {
+	colour := utils.NewColour(0.2, 0.4, 0.6)
+	background := NewSolidBackground(colour)
+
+	rng := random.New(1)
+	for i := 0; i < 100; i++ {
+		if got := background.Colour(rng.UnitVec3()); got != colour {
+			t.Fatalf("expected the solid colour %v in every direction, got %v", colour, got)
+		}
+	}
+}
+
+func TestGradientBackground(t *testing.T) {
+	// Without a Background, the renderer uses the gradient from white to the SkyColour.
+	opts := testOptions()
+	opts.SkyColour = utils.NewColour(0.5, 0.7, 1)
+	background := New(opts).opts.Background
+
+	// The formula that the renderer used before the backgrounds were configurable.
+	legacy := func(dir *utils.Vec3) *utils.Colour {
+		return utils.NewColour(1, 1, 1).Lerp(opts.SkyColour, 0.5*(dir.Y+1))
+	}
+
+	dirs := []*utils.Vec3{utils.NewVec3(0, 1, 0), utils.NewVec3(0, -1, 0), utils.NewVec3(1, 0, 0)}
+	rng := random.New(2)
+	for i := 0; i < 100; i++ {
+		dirs = append(dirs, rng.UnitVec3())
+	}
+
+	for _, dir := range dirs {
+		if got, want := background.Colour(dir), legacy(dir); !got.ApproxEqual(want, 1e-12) {
+			t.Errorf("expected the colour %v in the direction %v, got %v", want, dir, got)
+		}
+	}
+
+	// The extremes are the colours themselves.
+	if got := background.Colour(utils.NewVec3(0, 1, 0)); !got.ApproxEqual(opts.SkyColour, 1e-12) {
+		t.Errorf("expected the sky colour straight up, got %v", got)
+	}
+	if got := background.Colour(utils.NewVec3(0, -1, 0)); !got.ApproxEqual(utils.NewColour(1, 1, 1), 1e-12) {
+		t.Errorf("expected white straight down, got %v", got)
+	}
+}
+
+func TestSkyFunc(t *testing.T) {
+	// A sky that shows the direction of every ray as a colour.
+	opts := testOptions()
+	opts.Background = SkyFunc(func(dir *utils.Vec3) *utils.Colour {
+		return dir.Add(utils.NewVec3(1, 1, 1)).Mul(0.5).ToColour()
+	})
+	rend := New(opts)
+	world := shapes.NewGroup()
+
+	rng := random.New(3)
+	for i := 0; i < 100; i++ {
+		dir := rng.UnitVec3()
+		ray := utils.NewRay(utils.NewVec3(1, 2, 3), dir)
+		got := rend.traceRay(ray, world, rend.hitInterval(), 4, rend.newBounceBudget(), 0, &pixelContext{rng: rng})
+
+		want := utils.NewColour((dir.X+1)/2, (dir.Y+1)/2, (dir.Z+1)/2)
+		if !got.ApproxEqual(want, 1e-12) {
+			t.Fatalf("expected the colour %v of the direction %v, got %v", want, dir, got)
+		}
+	}
+
+	// The functions cannot be compared, so the scenes with them cannot be hashed.
+	if _, err := rend.Hash(world); !errors.Is(err, utils.ErrUnhashable) {
+		t.Errorf("expected ErrUnhashable for a SkyFunc, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package renderer
+
+import (
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// bounceBudget is the number of diffuse and specular bounces that a path has left,
+// along with the number of times that it can still be split.
+// It is a value type, so that every path (or branch of a path) spends its own copy.
+type bounceBudget struct {
+	diffuse, specular int
+	splits            int
+}
+
+// newBounceBudget returns the bounce budget of a new path, as per the options.
+func (r *Renderer) newBounceBudget() bounceBudget {
+	budget := bounceBudget{
+		diffuse: r.opts.MaxDiffuseBounces, specular: r.opts.MaxSpecularBounces, splits: r.opts.DielectricSplits,
+	}
+	if budget.diffuse <= 0 {
+		budget.diffuse = r.opts.MaxDiffusionDepth
+	}
+	if budget.specular <= 0 {
+		budget.specular = r.opts.MaxDiffusionDepth
+	}
+	return budget
+}
+
+// spend spends a bounce of the given lobe. It returns false if no bounces of its kind are left,
+// in which case the path should end.
+func (b *bounceBudget) spend(lobe mats.Lobe) bool {
+	counter := &b.specular
+	if lobe == mats.LobeDiffuse {
+		counter = &b.diffuse
+	}
+
+	if *counter <= 0 {
+		return false
+	}
+	*counter--
+	return true
+}
+
+// traceSplit traces both the reflected and the refracted rays off the given point-of-hit, and returns their
+// combined colour, weighted by the reflectance. The isSplit flag is false if the material does not implement
+// mats.Splitter, or the budget has no splits or specular bounces left, in which case the ray should be scattered.
+func (r *Renderer) traceSplit(
+	ray *utils.Ray, hitInfo *mats.RayHit, world shape, diffusionDepth int, budget bounceBudget, ctx *pixelContext,
+) (colour *utils.Colour, isSplit bool) {
+	splitter, ok := hitInfo.Mat.(mats.Splitter)
+	if !ok || budget.splits <= 0 {
+		return nil, false
+	}
+
+	reflected, refracted, reflectance, ok := splitter.Split(ray, hitInfo)
+	if !ok || !budget.spend(mats.LobeSpecular) {
+		return nil, false
+	}
+	budget.splits--
+
+	interval := r.surfaceInterval(hitInfo)
+	continuePath(reflected, ray)
+	colour = r.traceRay(reflected, world, interval, diffusionDepth-1, budget, 0, ctx).Scale(reflectance)
+
+	if refracted != nil {
+		continuePath(refracted, ray)
+		transmitted := r.traceRay(refracted, world, interval, diffusionDepth-1, budget, 0, ctx)
+		colour = colour.Add(transmitted.Scale(1 - reflectance))
+	}
+
+	return colour, true
+}
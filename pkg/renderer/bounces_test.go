@@ -0,0 +1,102 @@
+package renderer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/shapes"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestRenderer_DielectricSplits(t *testing.T) {
+	// A glass ball on a floor, under a light, which casts a shadow that only the light through the glass reaches.
+	light := shapes.NewAreaLight(
+		utils.NewVec3(-1, 4, -1), utils.NewVec3(2, 0, 0), utils.NewVec3(0, 0, 2), utils.NewColour(8, 8, 8))
+	ball := shapes.NewSphere(utils.NewVec3(0, 1, 0), 0.8, mats.NewGlass(1.5))
+	floor := shapes.NewQuad(utils.NewVec3(-5, 0, 5), utils.NewVec3(10, 0, 0), utils.NewVec3(0, 0, -10),
+		mats.NewMatte(utils.NewColour(0.8, 0.8, 0.8)))
+	world := shapes.NewGroup(light, ball, floor)
+
+	opts := testOptions()
+	opts.Background = NewSolidBackground(utils.NewColour(0, 0, 0))
+	opts.MaxDiffusionDepth = 8
+
+	// A ray that hits the floor right under the ball.
+	ray := utils.NewRay(utils.NewVec3(0.1, 0.1, 0.1), utils.NewVec3(0, -1, 0))
+
+	// The mean luminance of the light in the shadow, and the fraction of the samples that carry any light.
+	const samples = 40000
+	estimate := func(splits int) (mean, lit float64) {
+		opts := *opts
+		opts.DielectricSplits = splits
+		rend := New(&opts)
+		ctx := &pixelContext{rng: random.New(23)}
+
+		var sum float64
+		var litSamples int
+		for i := 0; i < samples; i++ {
+			luminance := rend.traceRay(ray, world, rend.hitInterval(), 8, rend.newBounceBudget(), 0, ctx).Luminance()
+			if luminance > 0 {
+				litSamples++
+			}
+			sum += luminance
+		}
+		return sum / samples, float64(litSamples) / samples
+	}
+
+	plainMean, plainLit := estimate(0)
+	splitMean, splitLit := estimate(3)
+
+	// Following both rays at the glass, instead of one at random, gets the light into more of the shadow samples.
+	if splitLit <= plainLit+0.02 {
+		t.Errorf("expected the splits to light more of the shadow samples, got %v against %v without them",
+			splitLit, plainLit)
+	}
+	// The shadow is lit through the glass either way, and the splits only spread the same light over the samples.
+	if plainMean <= 0 {
+		t.Fatalf("expected the light through the glass to reach the shadow, got %v", plainMean)
+	}
+	if math.Abs(splitMean-plainMean) > 0.03*plainMean {
+		t.Errorf("expected the splits to converge to the same light %v, got %v", plainMean, splitMean)
+	}
+}
+
+func TestRenderer_BounceCaps(t *testing.T) {
+	opts := testOptions()
+	opts.MaxDiffusionDepth, opts.MaxDiffuseBounces = 20, 2
+	rend := New(opts)
+
+	// The number of rays in the path of the given ray, in the given world.
+	pathRays := func(ray *utils.Ray, world shape) int {
+		ctx := &pixelContext{rng: random.New(3)}
+		rend.traceRay(ray, world, rend.hitInterval(), opts.MaxDiffusionDepth, rend.newBounceBudget(), 0, ctx)
+		return ctx.rays
+	}
+
+	// Inside a closed matte room, every ray hits a wall and scatters diffusely. The path ends after the
+	// 2 diffuse bounces, so it has the camera ray and the 2 scattered rays.
+	room := shapes.NewSphere(utils.NewVec3(0, 0, 0), 10, mats.NewMatte(utils.NewColour(0.8, 0.8, 0.8)))
+	for i := 0; i < 20; i++ {
+		ray := utils.NewRay(utils.NewVec3(0, 0, 0), utils.NewVec3(float64(i), 1, -1))
+		if got := pathRays(ray, room); got != 3 {
+			t.Fatalf("expected the diffuse path to end at the cap with 3 rays, got %d", got)
+		}
+	}
+
+	// Inside a glass ball, a ray that meets its surface at a grazing angle is totally reflected forever.
+	// The path is all glass, so it goes past the diffuse cap, up to the MaxDiffusionDepth.
+	ball := shapes.NewSphere(utils.NewVec3(0, 0, 0), 1, mats.NewGlass(1.5))
+	trapped := utils.NewRay(utils.NewVec3(0, 0.9, 0), utils.NewVec3(1, 0, 0))
+	if got := pathRays(trapped, ball); got != opts.MaxDiffusionDepth {
+		t.Errorf("expected the glass path to go on for %d rays, got %d", opts.MaxDiffusionDepth, got)
+	}
+
+	// With a specular cap as well, the glass path ends at it.
+	opts.MaxSpecularBounces = 5
+	rend = New(opts)
+	if got := pathRays(trapped, ball); got != 6 {
+		t.Errorf("expected the glass path to end at the specular cap with 6 rays, got %d", got)
+	}
+}
@@ -0,0 +1,87 @@
+package renderer
+
+import (
+	"math"
+
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// ClipPlane removes everything on one side of a plane from the scene, for cutaway renders.
+//
+// The solid shapes that the plane cuts through show a flat cap where they are cut open.
+type ClipPlane struct {
+	// Point is any point on the plane.
+	Point *utils.Vec3
+	// Normal of the plane. It points toward the side that is kept.
+	Normal *utils.Vec3
+
+	// CapColour is the colour of the cut faces. It defaults to grey.
+	CapColour *utils.Colour
+}
+
+// clippedShape is a shape whose hits are clipped by a ClipPlane.
+type clippedShape struct {
+	inner shape
+	plane *ClipPlane
+	// capMat is the material of the cut faces.
+	capMat mats.Material
+}
+
+// newClippedShape returns the given shape clipped by the given plane.
+func newClippedShape(inner shape, plane *ClipPlane) *clippedShape {
+	capColour := plane.CapColour
+	if capColour == nil {
+		capColour = utils.NewColour(0.5, 0.5, 0.5)
+	}
+
+	return &clippedShape{inner: inner, plane: plane, capMat: mats.NewDiffuseLight(capColour)}
+}
+
+func (c *clippedShape) Hit(ray *utils.Ray, interval utils.Interval) (*mats.RayHit, bool) {
+	normal := c.plane.Normal.Dir()
+
+	// Signed distance of the ray origin from the plane. It is positive on the kept side.
+	originSide := normal.Dot(ray.Origin.Sub(c.plane.Point))
+	// Rate at which the ray moves toward the kept side.
+	approach := normal.Dot(ray.Dir)
+
+	// If the ray starts on the kept side, only the hits before it crosses the plane count.
+	if originSide >= 0 {
+		if approach < 0 {
+			interval = interval.WithMax(math.Min(interval.Max, -originSide/approach))
+		}
+		return c.inner.Hit(ray, interval)
+	}
+
+	// The ray starts on the removed side and never reaches the kept side.
+	if approach <= 0 {
+		return nil, false
+	}
+
+	// Distance at which the ray reaches the kept side.
+	planeD := -originSide / approach
+	if planeD >= interval.Max {
+		return nil, false
+	}
+
+	rayHit, isHit := c.inner.Hit(ray, utils.NewInterval(math.Max(interval.Min, planeD), interval.Max))
+	// If the first hit on the kept side is from the inside of a shape,
+	// the plane cuts through that shape, and the ray sees the cap.
+	if isHit && !rayHit.IsRayOutside && planeD > interval.Min {
+		return &mats.RayHit{
+			Point:        ray.At(planeD),
+			Distance:     planeD,
+			Normal:       normal.Mul(-1),
+			IsRayOutside: true,
+			Mat:          c.capMat,
+		}, true
+	}
+
+	return rayHit, isHit
+}
+
+// BoundingBox returns the box of the inner shape. The clipping only ever removes parts of it.
+func (c *clippedShape) BoundingBox() utils.AABB {
+	return c.inner.BoundingBox()
+}
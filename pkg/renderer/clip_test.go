@@ -0,0 +1,60 @@
+package renderer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/shapes"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestClipPlane(t *testing.T) {
+	sphere := shapes.NewSphere(utils.NewVec3(0, 0, 0), 1, mats.NewMatte(utils.NewColour(0.2, 0.4, 0.8)))
+	capColour := utils.NewColour(1, 0.5, 0)
+	interval := utils.NewInterval(0, math.MaxFloat64)
+
+	// Only the far hemisphere is kept, so the rays from the front see the cap where it is cut open.
+	farHalf := newClippedShape(sphere, &ClipPlane{
+		Point: utils.NewVec3(0, 0, 0), Normal: utils.NewVec3(0, 0, -1), CapColour: capColour,
+	})
+
+	ray := utils.NewRay(utils.NewVec3(0.3, 0.2, 5), utils.NewVec3(0, 0, -1))
+	hit, isHit := farHalf.Hit(ray, interval)
+	if !isHit || !hit.Point.ApproxEqual(utils.NewVec3(0.3, 0.2, 0), 1e-9) {
+		t.Fatalf("expected the ray to hit the cap at (0.3, 0.2, 0), got %v", hit)
+	}
+	if got := emission(ray, hit); !got.ApproxEqual(capColour, 1e-9) {
+		t.Errorf("expected the cap to have its colour %v, got %v", capColour, got)
+	}
+	if !hit.Normal.ApproxEqual(utils.NewVec3(0, 0, 1), 1e-9) {
+		t.Errorf("expected the cap to face the ray, got the normal %v", hit.Normal)
+	}
+
+	// Beyond the silhouette of the sphere, there is no cap.
+	if _, isHit := farHalf.Hit(utils.NewRay(utils.NewVec3(1.1, 0, 5), utils.NewVec3(0, 0, -1)), interval); isHit {
+		t.Errorf("expected the ray beyond the sphere to miss")
+	}
+
+	// Only the near hemisphere is kept, so the far one is culled.
+	nearHalf := newClippedShape(sphere, &ClipPlane{Point: utils.NewVec3(0, 0, 0), Normal: utils.NewVec3(0, 0, 1)})
+
+	// The front is unaffected.
+	hit, isHit = nearHalf.Hit(ray, interval)
+	if wantZ := math.Sqrt(1 - 0.3*0.3 - 0.2*0.2); !isHit || math.Abs(hit.Point.Z-wantZ) > 1e-9 || hit.Mat != sphere.Mat {
+		t.Errorf("expected the ray to hit the front of the sphere at z = %v, got %v", wantZ, hit)
+	}
+
+	// A ray that only crosses the far hemisphere misses, and a ray from behind sees the default grey cap.
+	if _, isHit := nearHalf.Hit(utils.NewRay(utils.NewVec3(5, 0, -0.5), utils.NewVec3(-1, 0, 0)), interval); isHit {
+		t.Errorf("expected the ray through the culled hemisphere to miss")
+	}
+	fromBehind := utils.NewRay(utils.NewVec3(0.3, 0.2, -5), utils.NewVec3(0, 0, 1))
+	hit, isHit = nearHalf.Hit(fromBehind, interval)
+	if !isHit || !hit.Point.ApproxEqual(utils.NewVec3(0.3, 0.2, 0), 1e-9) {
+		t.Fatalf("expected the ray from behind to hit the cap at (0.3, 0.2, 0), got %v", hit)
+	}
+	if got := emission(fromBehind, hit); !got.ApproxEqual(utils.NewColour(0.5, 0.5, 0.5), 1e-9) {
+		t.Errorf("expected the cap to be grey by default, got %v", got)
+	}
+}
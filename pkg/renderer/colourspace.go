@@ -0,0 +1,71 @@
+package renderer
+
+import (
+	"math"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// ColourSpace determines the transfer function that converts the linear colours of the render
+// into the values stored in the output image.
+type ColourSpace int
+
+const (
+	// ColourSpaceGamma2 applies a plain gamma of 2, that is, a square root. It is cheap, but
+	// differs from the standard curves, especially near black.
+	ColourSpaceGamma2 ColourSpace = iota
+	// ColourSpaceLinear stores the linear colours as they are, which is useful for further processing.
+	ColourSpaceLinear
+	// ColourSpaceSRGB applies the piecewise sRGB transfer function, which is what most displays expect.
+	// To know more, visit-
+	// https://en.wikipedia.org/wiki/SRGB#Transfer_function_(%22gamma%22)
+	ColourSpaceSRGB
+	// ColourSpaceRec709 applies the piecewise Rec. 709 transfer function, used for HD video.
+	// To know more, visit-
+	// https://en.wikipedia.org/wiki/Rec._709#Transfer_characteristics
+	ColourSpaceRec709
+)
+
+// encode converts the given linear colour into the colour space.
+func (c ColourSpace) encode(colour *utils.Colour) *utils.Colour {
+	return utils.NewColour(c.encodeComponent(colour.R), c.encodeComponent(colour.G), c.encodeComponent(colour.B))
+}
+
+// decode converts the given colour from the colour space back to linear.
+func (c ColourSpace) decode(colour *utils.Colour) *utils.Colour {
+	return utils.NewColour(c.decodeComponent(colour.R), c.decodeComponent(colour.G), c.decodeComponent(colour.B))
+}
+
+// encodeComponent applies the transfer function to a single linear colour component.
+func (c ColourSpace) encodeComponent(value float64) float64 {
+	switch c {
+	case ColourSpaceLinear:
+		return value
+	case ColourSpaceSRGB:
+		return utils.LinearToSRGB(value)
+	case ColourSpaceRec709:
+		if value < 0.018 {
+			return 4.5 * value
+		}
+		return 1.099*math.Pow(value, 0.45) - 0.099
+	default:
+		return math.Sqrt(value)
+	}
+}
+
+// decodeComponent applies the inverse of the transfer function to a single colour component.
+func (c ColourSpace) decodeComponent(value float64) float64 {
+	switch c {
+	case ColourSpaceLinear:
+		return value
+	case ColourSpaceSRGB:
+		return utils.SRGBToLinear(value)
+	case ColourSpaceRec709:
+		if value < 0.081 {
+			return value / 4.5
+		}
+		return math.Pow((value+0.099)/1.099, 1/0.45)
+	default:
+		return value * value
+	}
+}
@@ -0,0 +1,126 @@
+package renderer
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+const (
+	// edgeNormalCosine is the cosine of the smallest angle between the normals of neighbouring pixels
+	// that makes them an edge.
+	edgeNormalCosine = 0.9
+	// edgeDepthRatio is the smallest relative difference between the depths of neighbouring pixels
+	// that makes them an edge.
+	edgeDepthRatio = 0.1
+)
+
+// edgeProbe is what a ray through the center of a pixel sees. It is used to detect the edges.
+type edgeProbe struct {
+	// isHit is false for the pixels that see the background, in which case the other fields are empty.
+	isHit  bool
+	depth  float64
+	normal *utils.Vec3
+	mat    mats.Material
+}
+
+// renderEdgeAdaptive renders the region into the given pixels, giving the full samples per pixel only
+// to the pixels on the edges, and FlatSamplesPerPixel to all others.
+//
+// An edge is detected by casting a ray through the center of every pixel, and comparing what it sees
+// with the neighbouring pixels. Silhouettes, creases and material boundaries count as edges.
+//
+// If the bounce layers or the heat map are given, they are filled in as well.
+func (r *Renderer) renderEdgeAdaptive(
+	world shape, region image.Rectangle, pixels []*utils.Colour, layers *bounceLayers, heat *heatMap,
+) {
+	width := int(r.opts.ImageWidth)
+
+	flatSamples := r.opts.FlatSamplesPerPixel
+	if flatSamples < 1 {
+		flatSamples = 1
+	}
+
+	// Edge detection pass.
+	probes := make([]edgeProbe, len(pixels))
+	r.forEachPixel(region, func(x, y int) {
+		probes[y*width+x] = r.probeEdge(float64(x), r.flipY(y), world)
+	})
+	edges := r.detectEdges(region, probes)
+
+	// Rendering pass.
+	r.forEachPixel(region, func(x, y int) {
+		idx := y*width + x
+		count := flatSamples
+		if edges[idx] {
+			count = r.opts.SamplesPerPixel
+		}
+
+		samples := r.samplePixel(float64(x), r.flipY(y), world, 0, count)
+		pixels[idx] = r.resolvePixel(samples.sum, samples.weight)
+		layers.store(r, idx, samples)
+		heat.store(idx, samples)
+	})
+
+	if r.opts.Progress != nil && !region.Empty() {
+		edgeCount := 0
+		for _, isEdge := range edges {
+			if isEdge {
+				edgeCount++
+			}
+		}
+		_, _ = fmt.Fprintf(r.opts.Progress, "Edge pixels: %d of %d\n", edgeCount, region.Dx()*region.Dy())
+	}
+}
+
+// probeEdge casts a ray through the center of the given pixel (and the lens) and returns what it sees.
+func (r *Renderer) probeEdge(x, y float64, world shape) edgeProbe {
+	// Bring x and y in the [0, 1) interval, with the ray going through the pixel center.
+	x = (x + 0.5) / (r.opts.ImageWidth - 1)
+	y = (y + 0.5) / (r.opts.ImageHeight - 1)
+
+	r.rays.Add(1)
+	hitInfo, isHit := world.Hit(r.opts.Camera.CastCenterRay(x, y), r.hitInterval())
+	if !isHit {
+		return edgeProbe{}
+	}
+
+	return edgeProbe{isHit: true, depth: hitInfo.Distance, normal: hitInfo.Normal, mat: hitInfo.Mat}
+}
+
+// detectEdges returns whether every pixel of the region differs from any of its neighbours enough to be an edge.
+func (r *Renderer) detectEdges(region image.Rectangle, probes []edgeProbe) []bool {
+	width := int(r.opts.ImageWidth)
+
+	edges := make([]bool, len(probes))
+	for y := region.Min.Y; y < region.Max.Y; y++ {
+		for x := region.Min.X; x < region.Max.X; x++ {
+			for _, neighbour := range [4]image.Point{{x - 1, y}, {x + 1, y}, {x, y - 1}, {x, y + 1}} {
+				if neighbour.In(region) && isEdge(probes[y*width+x], probes[neighbour.Y*width+neighbour.X]) {
+					edges[y*width+x] = true
+					break
+				}
+			}
+		}
+	}
+
+	return edges
+}
+
+// isEdge tells whether there is an edge between the given probes of neighbouring pixels.
+func isEdge(a, b edgeProbe) bool {
+	if a.isHit != b.isHit {
+		return true
+	}
+	// Both see the background.
+	if !a.isHit {
+		return false
+	}
+
+	return a.mat != b.mat ||
+		a.normal.Dot(b.normal) < edgeNormalCosine ||
+		math.Abs(a.depth-b.depth) > edgeDepthRatio*math.Min(a.depth, b.depth)
+}
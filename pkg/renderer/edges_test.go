@@ -0,0 +1,61 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/shapes"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestRenderer_EdgeAdaptive(t *testing.T) {
+	world := shapes.NewGroup(
+		shapes.NewSphere(utils.NewVec3(0, 0, -1), 0.5, mats.NewMatte(utils.NewColour(0.8, 0.3, 0.2))),
+	)
+
+	opts := straightOptions()
+	opts.EdgeAdaptive, opts.SamplesPerPixel, opts.FlatSamplesPerPixel = true, 16, 2
+	rend := New(opts)
+
+	width, height := int(opts.ImageWidth), int(opts.ImageHeight)
+	region := rend.region()
+	probes := make([]edgeProbe, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			probes[y*width+x] = rend.probeEdge(float64(x), rend.flipY(y), world)
+		}
+	}
+	edges := rend.detectEdges(region, probes)
+
+	// The pixels on the rim of the sphere, which see the sky on one side, are edges. The middle of the sphere,
+	// where the normals and depths change slowly, and the sky are not.
+	var edgeCount int
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			idx := y*width + x
+			if edges[idx] {
+				edgeCount++
+			}
+
+			isRim := x+1 < width && probes[idx].isHit != probes[idx+1].isHit
+			if isRim && (!edges[idx] || !edges[idx+1]) {
+				t.Errorf("expected the rim pixels (%d, %d) and (%d, %d) to be edges", x, y, x+1, y)
+			}
+		}
+	}
+	for _, idx := range []int{height/2*width + width/2, 0, height*width - 1} {
+		if edges[idx] {
+			t.Errorf("expected the flat pixel %d not to be an edge", idx)
+		}
+	}
+	if edgeCount == 0 || edgeCount > width*height/4 {
+		t.Fatalf("expected a thin ring of edges, got %d of %d pixels", edgeCount, width*height)
+	}
+
+	// Only the edges get the full samples per pixel, and all other pixels get the flat samples.
+	rend.renderPasses(world)
+	want := int64(edgeCount*opts.SamplesPerPixel + (width*height-edgeCount)*opts.FlatSamplesPerPixel)
+	if got := rend.samples.Load(); got != want {
+		t.Errorf("expected %d samples for %d edge pixels, got %d", want, edgeCount, got)
+	}
+}
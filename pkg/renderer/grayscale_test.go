@@ -0,0 +1,38 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestRenderer_Grayscale(t *testing.T) {
+	world := testScene()
+	colourPixels, _, _ := New(testOptions()).renderPasses(world)
+
+	opts := testOptions()
+	opts.Grayscale = true
+	rend := New(opts)
+	grayPixels, _, _ := rend.renderPasses(world)
+
+	// With the same seed, the samples are the same, so every gray pixel is the luminance of the colour pixel.
+	// The luminance is taken before the gamma correction.
+	var hasColour bool
+	for idx, gray := range grayPixels {
+		if gray.R != gray.G || gray.G != gray.B {
+			t.Fatalf("pixel %d: expected equal channels, got %v", idx, gray)
+		}
+
+		linear := opts.OutputColourSpace.decode(colourPixels[idx])
+		luminance := linear.Luminance()
+		want := opts.OutputColourSpace.encode(utils.NewColour(luminance, luminance, luminance))
+		if !gray.ApproxEqual(want, 1e-9) {
+			t.Fatalf("pixel %d: expected the luminance %v of %v, got %v", idx, want, colourPixels[idx], gray)
+		}
+
+		hasColour = hasColour || colourPixels[idx].R != colourPixels[idx].B
+	}
+	if !hasColour {
+		t.Errorf("expected the scene to have some colourful pixels")
+	}
+}
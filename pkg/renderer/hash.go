@@ -0,0 +1,28 @@
+package renderer
+
+import (
+	"fmt"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// Hash returns a stable hash of the given world and all the options that affect the rendered image.
+// It can be used to name the outputs of a render farm, and skip the scenes that are already rendered.
+//
+// The options that do not affect the image, like the worker pool settings, the progress writer and
+// the output file, are not a part of the hash.
+//
+// Functions cannot be compared, so it returns an error wrapping utils.ErrUnhashable if the options or
+// the world hold one, like a SkyFunc background. Otherwise, different functions would give the same hash.
+func (r *Renderer) Hash(world shape) (uint64, error) {
+	opts := *r.opts
+	opts.MaxWorkers, opts.PoolStrategy, opts.QueueSize = 0, nil, 0
+	opts.Progress, opts.OutputFile = nil, ""
+
+	hash, err := utils.HashStrict(&opts, world)
+	if err != nil {
+		return 0, fmt.Errorf("failed to hash the render: %w", err)
+	}
+
+	return hash, nil
+}
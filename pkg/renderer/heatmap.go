@@ -0,0 +1,67 @@
+package renderer
+
+import (
+	"math"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// heatMap holds the costs of all pixels for the ModeHeatMap mode, in row-major order with top-left as the origin.
+// The cost of a pixel is the average number of rays traced per sample.
+type heatMap struct {
+	costs []float64
+}
+
+// newHeatMap returns a new heatMap for the given number of pixels.
+func newHeatMap(pixelCount int) *heatMap {
+	return &heatMap{costs: make([]float64, pixelCount)}
+}
+
+// store stores the cost of the pixel at the given index, using the given samples.
+// It does nothing if the heat map is nil.
+func (h *heatMap) store(idx int, samples *pixelSamples) {
+	if h == nil || samples.count == 0 {
+		return
+	}
+	h.costs[idx] = float64(samples.rays) / float64(samples.count)
+}
+
+// apply replaces the colours of the given pixels with their heat map colours, relative to the costliest pixel.
+// Nil pixels are left as they are. It does nothing if the heat map is nil.
+func (h *heatMap) apply(pixels []*utils.Colour) {
+	if h == nil {
+		return
+	}
+
+	maxCost := 0.0
+	for _, cost := range h.costs {
+		maxCost = math.Max(maxCost, cost)
+	}
+
+	for idx, pixel := range pixels {
+		if pixel != nil && maxCost > 0 {
+			pixels[idx] = heatColour(h.costs[idx] / maxCost)
+		}
+	}
+}
+
+// heatColours are the colours of the heat map, from the lowest to the highest cost.
+var heatColours = []*utils.Colour{
+	utils.NewColour(0, 0, 1), // Blue.
+	utils.NewColour(0, 1, 1), // Cyan.
+	utils.NewColour(0, 1, 0), // Green.
+	utils.NewColour(1, 1, 0), // Yellow.
+	utils.NewColour(1, 0, 0), // Red.
+}
+
+// heatColour returns the heat map colour for the given relative cost, which is expected to be in [0, 1].
+func heatColour(cost float64) *utils.Colour {
+	// Position of the cost on the colour ramp.
+	position := cost * float64(len(heatColours)-1)
+	index := int(position)
+	if index >= len(heatColours)-1 {
+		return heatColours[len(heatColours)-1]
+	}
+
+	return heatColours[index].Lerp(heatColours[index+1], position-float64(index))
+}
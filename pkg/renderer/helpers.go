@@ -1,6 +1,7 @@
 package renderer
 
 import (
+	"bytes"
 	"fmt"
 	"image"
 	"image/color"
@@ -10,21 +11,67 @@ import (
 	"os"
 	"path/filepath"
 
+	"golang.org/x/image/bmp"
+	"golang.org/x/image/tiff"
+
 	"github.com/shivanshkc/lightshow/pkg/shapes"
+	"github.com/shivanshkc/lightshow/pkg/utils"
 )
 
 // Type alias for shape.
 type shape = shapes.Shape
 
+// buildImage creates an image from the given pixel colours, which are expected to be
+// in row-major order with top-left as the origin.
+//
+// Nil colours are treated as pixels that were not rendered and are left transparent.
+//
+// A bitDepth of 16 creates an image with 16 bits per channel. Otherwise, 8 bits are used.
+func buildImage(pixels []*utils.Colour, width, height, bitDepth int) image.Image {
+	bounds := image.Rect(0, 0, width, height)
+
+	if bitDepth == 16 {
+		img := image.NewRGBA64(bounds)
+		for idx, colour := range pixels {
+			if colour != nil {
+				img.SetRGBA64(idx%width, idx/width, colour.ToStd64())
+			}
+		}
+		return img
+	}
+
+	img := image.NewRGBA(bounds)
+	for idx, colour := range pixels {
+		if colour != nil {
+			img.Set(idx%width, idx/width, colour.ToStd())
+		}
+	}
+	return img
+}
+
+// encodeOptions are the format-specific settings for encoding an image.
+type encodeOptions struct {
+	// metadata is embedded into PNG and JPG files. Other formats ignore it.
+	metadata []metadataEntry
+	// jpegQuality is the quality of JPG files, from 1 to 100.
+	jpegQuality int
+}
+
 // encodeImage encodes the given image into the outFile.
 // It infers the format of the image using the file extension.
 // If the file has an unknown or no extension, it defaults to PNG.
-func encodeImage(img image.Image, outFile string) error {
+//
+// The given options may be nil, in which case the defaults are used.
+func encodeImage(img image.Image, outFile string, opts *encodeOptions) error {
+	if opts == nil {
+		opts = &encodeOptions{jpegQuality: jpeg.DefaultQuality}
+	}
+
 	// Obtain the file extension to decide on the encoder.
 	extension := filepath.Ext(outFile)
 
 	// Open the output image file.
-	imageFile, err := os.OpenFile(outFile, os.O_CREATE|os.O_WRONLY, os.ModePerm)
+	imageFile, err := os.OpenFile(outFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.ModePerm)
 	if err != nil {
 		return fmt.Errorf("failed to open image file: %w", err)
 	}
@@ -33,28 +80,64 @@ func encodeImage(img image.Image, outFile string) error {
 
 	switch extension {
 	case ".jpeg", ".jpg":
-		return encodeJPG(img, imageFile)
+		return encodeJPG(img, imageFile, opts.jpegQuality, opts.metadata)
 	case ".ppm":
 		return encodePPM(img, imageFile)
+	case ".bmp":
+		return encodeBMP(img, imageFile)
+	case ".tif", ".tiff":
+		return encodeTIFF(img, imageFile)
 	default:
-		return encodePNG(img, imageFile)
+		return encodePNG(img, imageFile, opts.metadata)
 	}
 }
 
 // encodePNG encodes the given image.Image instance as a PNG into the outFile.
-func encodePNG(img image.Image, file io.Writer) error {
+// The metadata, if any, is embedded as tEXt chunks.
+func encodePNG(img image.Image, file io.Writer, metadata []metadataEntry) error {
 	// Encode the image data.
-	if err := png.Encode(file, img); err != nil {
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, img); err != nil {
 		return fmt.Errorf("failed to encode image: %w", err)
 	}
 
+	if _, err := file.Write(withPNGText(buffer.Bytes(), metadata)); err != nil {
+		return fmt.Errorf("error in file.Write call: %w", err)
+	}
+
 	return nil
 }
 
 // encodeJPG encodes the given image.Image instance as a JPG into the outFile.
-func encodeJPG(img image.Image, file io.Writer) error {
+// The quality ranges from 1 to 100, higher is better. The metadata, if any, is embedded as a comment.
+func encodeJPG(img image.Image, file io.Writer, quality int, metadata []metadataEntry) error {
+	// Encode the image data.
+	var buffer bytes.Buffer
+	if err := jpeg.Encode(&buffer, img, &jpeg.Options{Quality: quality}); err != nil {
+		return fmt.Errorf("failed to encode image: %w", err)
+	}
+
+	if _, err := file.Write(withJPEGComment(buffer.Bytes(), metadata)); err != nil {
+		return fmt.Errorf("error in file.Write call: %w", err)
+	}
+
+	return nil
+}
+
+// encodeBMP encodes the given image.Image instance as a BMP into the outFile.
+func encodeBMP(img image.Image, file io.Writer) error {
+	// Encode the image data.
+	if err := bmp.Encode(file, img); err != nil {
+		return fmt.Errorf("failed to encode image: %w", err)
+	}
+
+	return nil
+}
+
+// encodeTIFF encodes the given image.Image instance as a TIFF into the outFile.
+func encodeTIFF(img image.Image, file io.Writer) error {
 	// Encode the image data.
-	if err := jpeg.Encode(file, img, &jpeg.Options{Quality: 100}); err != nil {
+	if err := tiff.Encode(file, img, &tiff.Options{Compression: tiff.Deflate}); err != nil {
 		return fmt.Errorf("failed to encode image: %w", err)
 	}
 
@@ -76,8 +159,8 @@ func encodePPM(img image.Image, file io.Writer) error {
 	// Loop over each pixel.
 	for y := 0; y < height; y++ {
 		for x := 0; x < width; x++ {
-			// Convert the pixel colour to RGBA.
-			col, asserted := img.At(x, y).(color.RGBA)
+			// Convert the pixel colour to 8-bit RGBA.
+			col, asserted := color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
 			if !asserted {
 				return fmt.Errorf("image contains invalid pixel value")
 			}
@@ -0,0 +1,142 @@
+package renderer
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/image/bmp"
+	"golang.org/x/image/tiff"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// testPixels returns the colours of a small image with a different colour in every pixel.
+func testPixels(width, height int) []*utils.Colour {
+	pixels := make([]*utils.Colour, 0, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			pixels = append(pixels, utils.NewColour(float64(x)/float64(width), float64(y)/float64(height), 0.5))
+		}
+	}
+	return pixels
+}
+
+// decodeFile decodes the image file at the given path with the given decoder.
+func decodeFile(t *testing.T, path string, decode func(file *os.File) (image.Image, error)) image.Image {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	img, err := decode(file)
+	if err != nil {
+		t.Fatalf("failed to decode %s: %v", path, err)
+	}
+	return img
+}
+
+func TestEncodeImage_BMPAndTIFF(t *testing.T) {
+	const width, height = 8, 6
+	img := buildImage(testPixels(width, height), width, height, 8)
+
+	tests := []struct {
+		file   string
+		decode func(file *os.File) (image.Image, error)
+	}{
+		{file: "image.bmp", decode: func(file *os.File) (image.Image, error) { return bmp.Decode(file) }},
+		{file: "image.tif", decode: func(file *os.File) (image.Image, error) { return tiff.Decode(file) }},
+		{file: "image.tiff", decode: func(file *os.File) (image.Image, error) { return tiff.Decode(file) }},
+	}
+
+	for _, test := range tests {
+		path := filepath.Join(t.TempDir(), test.file)
+		if err := encodeImage(img, path, nil); err != nil {
+			t.Fatalf("%s: failed to encode: %v", test.file, err)
+		}
+
+		decoded := decodeFile(t, path, test.decode)
+		if decoded.Bounds() != img.Bounds() {
+			t.Fatalf("%s: expected the bounds %v, got %v", test.file, img.Bounds(), decoded.Bounds())
+		}
+
+		// Both formats are lossless, so every pixel survives the round trip.
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				want, got := color.RGBAModel.Convert(img.At(x, y)), color.RGBAModel.Convert(decoded.At(x, y))
+				if got != want {
+					t.Errorf("%s: expected %v at (%d, %d), got %v", test.file, want, x, y, got)
+				}
+			}
+		}
+	}
+}
+
+func TestEncodeImage_PNG16(t *testing.T) {
+	// Nearby values, which share the same 8-bit code.
+	pixels := []*utils.Colour{
+		utils.NewColour(0.5, 0.5, 0.5), utils.NewColour(0.501, 0.501, 0.501), utils.NewColour(0.502, 0.502, 0.502),
+	}
+
+	// The red channel of every pixel of the image, encoded as a PNG with the given bit depth and decoded back.
+	codes := func(bitDepth int) []uint32 {
+		path := filepath.Join(t.TempDir(), "image.png")
+		if err := encodeImage(buildImage(pixels, len(pixels), 1, bitDepth), path, nil); err != nil {
+			t.Fatalf("failed to encode: %v", err)
+		}
+
+		decoded := decodeFile(t, path, func(file *os.File) (image.Image, error) { return png.Decode(file) })
+		if bitDepth == 16 {
+			if _, ok := decoded.(*image.RGBA64); !ok {
+				t.Errorf("expected a 16-bit image, got %T", decoded)
+			}
+		}
+
+		result := make([]uint32, len(pixels))
+		for x := range pixels {
+			result[x], _, _, _ = decoded.At(x, 0).RGBA()
+		}
+		return result
+	}
+
+	if got := codes(8); got[0] != got[1] || got[1] != got[2] {
+		t.Errorf("expected the nearby values to share an 8-bit code, got %v", got)
+	}
+	if got := codes(16); got[0] >= got[1] || got[1] >= got[2] {
+		t.Errorf("expected the nearby values to get distinct, increasing 16-bit codes, got %v", got)
+	}
+}
+
+func TestEncodeImage_JPEGQuality(t *testing.T) {
+	const width, height = 64, 48
+	img := buildImage(testPixels(width, height), width, height, 8)
+
+	// The size of the image, encoded as a JPEG with the given quality.
+	size := func(quality int) int64 {
+		path := filepath.Join(t.TempDir(), "image.jpg")
+		if err := encodeImage(img, path, &encodeOptions{jpegQuality: quality}); err != nil {
+			t.Fatalf("failed to encode with the quality %d: %v", quality, err)
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("failed to stat the image: %v", err)
+		}
+		return info.Size()
+	}
+
+	previous := size(100)
+	for _, quality := range []int{90, 50, 10} {
+		got := size(quality)
+		if got >= previous {
+			t.Errorf("expected the quality %d to give a smaller file than %d bytes, got %d", quality, previous, got)
+		}
+		previous = got
+	}
+}
@@ -0,0 +1,140 @@
+package renderer
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+const (
+	// importanceBaseFraction is the fraction of the samples per pixel that every pixel gets
+	// in the first pass of importance sampling.
+	importanceBaseFraction = 0.25
+	// importanceMaxFactor limits the samples of a single pixel to this multiple of the samples per pixel.
+	importanceMaxFactor = 16
+)
+
+// renderImportanceSampled renders the region into the given pixels in two passes.
+//
+// The first pass gives every pixel a fraction of the samples and measures how much each pixel
+// contrasts with its neighbours. The second pass distributes the remaining samples in proportion
+// to that contrast. Unlike adaptive sampling, no threshold is involved.
+//
+// If the bounce layers or the heat map are given, they are filled in as well.
+func (r *Renderer) renderImportanceSampled(
+	world shape, region image.Rectangle, pixels []*utils.Colour, layers *bounceLayers, heat *heatMap,
+) {
+	width := int(r.opts.ImageWidth)
+
+	baseSamples := int(float64(r.opts.SamplesPerPixel) * importanceBaseFraction)
+	if baseSamples < 1 {
+		baseSamples = 1
+	}
+
+	// First pass.
+	samples := make([]*pixelSamples, len(pixels))
+	r.forEachPixel(region, func(x, y int) {
+		samples[y*width+x] = r.samplePixel(float64(x), r.flipY(y), world, 0, baseSamples)
+	})
+
+	// Second pass.
+	extraSamples := r.allocateSamples(region, samples, baseSamples)
+	r.forEachPixel(region, func(x, y int) {
+		idx := y*width + x
+		if extra := extraSamples[idx]; extra > 0 {
+			samples[idx] = samples[idx].add(r.samplePixel(float64(x), r.flipY(y), world, baseSamples, extra))
+		}
+
+		pixels[idx] = r.resolvePixel(samples[idx].sum, samples[idx].weight)
+		layers.store(r, idx, samples[idx])
+		heat.store(idx, samples[idx])
+	})
+
+	r.reportSampleDistribution(region, extraSamples, baseSamples)
+}
+
+// allocateSamples distributes the remaining sample budget among the pixels of the region,
+// in proportion to their contrast with their neighbours.
+//
+// The samples are the results of the first pass, with the given number of samples each.
+// It returns the number of extra samples for every pixel.
+func (r *Renderer) allocateSamples(region image.Rectangle, samples []*pixelSamples, baseSamples int) []int {
+	width := int(r.opts.ImageWidth)
+
+	// Luminance of the average colour of every pixel.
+	luminances := make([]float64, len(samples))
+	for idx, pixel := range samples {
+		if pixel != nil && pixel.weight > 0 {
+			luminances[idx] = pixel.sum.Luminance() / pixel.weight
+		}
+	}
+
+	// The importance of a pixel is its biggest luminance difference with a neighbour.
+	importances := make([]float64, len(samples))
+	totalImportance := 0.0
+	for y := region.Min.Y; y < region.Max.Y; y++ {
+		for x := region.Min.X; x < region.Max.X; x++ {
+			for _, neighbour := range [4]image.Point{{x - 1, y}, {x + 1, y}, {x, y - 1}, {x, y + 1}} {
+				if !neighbour.In(region) {
+					continue
+				}
+
+				diff := math.Abs(luminances[y*width+x] - luminances[neighbour.Y*width+neighbour.X])
+				importances[y*width+x] = math.Max(importances[y*width+x], diff)
+			}
+			totalImportance += importances[y*width+x]
+		}
+	}
+
+	budget := float64((r.opts.SamplesPerPixel - baseSamples) * region.Dx() * region.Dy())
+	maxExtra := importanceMaxFactor*r.opts.SamplesPerPixel - baseSamples
+
+	extraSamples := make([]int, len(samples))
+	for y := region.Min.Y; y < region.Max.Y; y++ {
+		for x := region.Min.X; x < region.Max.X; x++ {
+			// A completely flat image gets the same number of samples everywhere.
+			share := 1 / float64(region.Dx()*region.Dy())
+			if totalImportance > 0 {
+				share = importances[y*width+x] / totalImportance
+			}
+
+			extra := int(math.Round(budget * share))
+			if extra > maxExtra {
+				extra = maxExtra
+			}
+			extraSamples[y*width+x] = extra
+		}
+	}
+
+	return extraSamples
+}
+
+// reportSampleDistribution writes the minimum, mean and maximum samples per pixel to the progress writer.
+func (r *Renderer) reportSampleDistribution(region image.Rectangle, extraSamples []int, baseSamples int) {
+	if r.opts.Progress == nil || region.Empty() {
+		return
+	}
+
+	width := int(r.opts.ImageWidth)
+	minSamples, maxSamples, total := math.MaxInt, 0, 0
+
+	for y := region.Min.Y; y < region.Max.Y; y++ {
+		for x := region.Min.X; x < region.Max.X; x++ {
+			samples := baseSamples + extraSamples[y*width+x]
+			total += samples
+
+			if samples < minSamples {
+				minSamples = samples
+			}
+			if samples > maxSamples {
+				maxSamples = samples
+			}
+		}
+	}
+
+	mean := float64(total) / float64(region.Dx()*region.Dy())
+	_, _ = fmt.Fprintf(r.opts.Progress, "Samples per pixel: min %d, mean %.1f, max %d\n",
+		minSamples, mean, maxSamples)
+}
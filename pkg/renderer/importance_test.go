@@ -0,0 +1,60 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/shapes"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestRenderer_AllocateSamples(t *testing.T) {
+	// A bright light in the middle of a dark scene.
+	light := shapes.NewSphere(utils.NewVec3(0, 0, -1), 0.4, mats.NewDiffuseLight(utils.NewColour(10, 10, 10)))
+	world := shapes.NewGroup(light)
+
+	opts := straightOptions()
+	opts.Background = NewSolidBackground(utils.NewColour(0, 0, 0))
+	opts.SamplesPerPixel, opts.ImportanceSampling = 16, true
+	rend := New(opts)
+
+	// The first pass.
+	width, height := int(opts.ImageWidth), int(opts.ImageHeight)
+	region, baseSamples := rend.region(), 4
+	samples := make([]*pixelSamples, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			samples[y*width+x] = rend.samplePixel(float64(x), rend.flipY(y), world, 0, baseSamples)
+		}
+	}
+	extraSamples := rend.allocateSamples(region, samples, baseSamples)
+
+	// The pixels on the rim of the light, which contrast with their neighbours, get more samples than
+	// the average, while the flat pixels far from it get none.
+	var rim int
+	middle := height / 2
+	for x := 1; x < width; x++ {
+		idx := middle*width + x
+		isLight, isNeighbourLight := samples[idx].sum.Luminance() > 0, samples[idx-1].sum.Luminance() > 0
+		if isLight != isNeighbourLight && extraSamples[idx] > rim {
+			rim = extraSamples[idx]
+		}
+	}
+	if average := opts.SamplesPerPixel - baseSamples; rim <= average {
+		t.Errorf("expected the rim of the light to get more than the average of %d extra samples, got %d", average, rim)
+	}
+	for _, idx := range []int{0, width - 1, (height - 1) * width, height*width - 1} {
+		if extraSamples[idx] != 0 {
+			t.Errorf("expected no extra samples for the flat corner %d, got %d", idx, extraSamples[idx])
+		}
+	}
+
+	// The budget of the second pass is kept, apart from the rounding.
+	var total int
+	for _, extra := range extraSamples {
+		total += extra
+	}
+	if budget := (opts.SamplesPerPixel - baseSamples) * width * height; total < budget*95/100 || total > budget*105/100 {
+		t.Errorf("expected about %d extra samples in total, got %d", budget, total)
+	}
+}
@@ -0,0 +1,55 @@
+package renderer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// bounceLayers holds the final colours of the direct and indirect illumination layers,
+// in row-major order with top-left as the origin.
+type bounceLayers struct {
+	direct, indirect []*utils.Colour
+}
+
+// newBounceLayers returns new bounceLayers for the given number of pixels.
+func newBounceLayers(pixelCount int) *bounceLayers {
+	return &bounceLayers{
+		direct:   make([]*utils.Colour, pixelCount),
+		indirect: make([]*utils.Colour, pixelCount),
+	}
+}
+
+// store resolves and stores the layers of the pixel at the given index, using the given samples.
+// It does nothing if the layers are nil.
+func (b *bounceLayers) store(r *Renderer, idx int, samples *pixelSamples) {
+	if b == nil {
+		return
+	}
+
+	// Rounding errors can make the difference slightly negative, which the gamma correction cannot handle.
+	sum, directSum := samples.sum, samples.directSum
+	indirectSum := utils.NewColour(
+		math.Max(sum.R-directSum.R, 0),
+		math.Max(sum.G-directSum.G, 0),
+		math.Max(sum.B-directSum.B, 0),
+	)
+	b.direct[idx] = r.resolvePixel(directSum, samples.weight)
+	b.indirect[idx] = r.resolvePixel(indirectSum, samples.weight)
+}
+
+// encode writes the layers next to the output file, with the "-direct" and "-indirect" suffixes.
+func (b *bounceLayers) encode(r *Renderer, width, height int) error {
+	directImg := buildImage(b.direct, width, height, r.opts.BitDepth)
+	if err := encodeImage(directImg, r.auxiliaryFile("direct"), nil); err != nil {
+		return fmt.Errorf("failed to encode direct layer: %w", err)
+	}
+
+	indirectImg := buildImage(b.indirect, width, height, r.opts.BitDepth)
+	if err := encodeImage(indirectImg, r.auxiliaryFile("indirect"), nil); err != nil {
+		return fmt.Errorf("failed to encode indirect layer: %w", err)
+	}
+
+	return nil
+}
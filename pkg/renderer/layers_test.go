@@ -0,0 +1,43 @@
+package renderer
+
+import "testing"
+
+func TestRenderer_BounceLayers(t *testing.T) {
+	world := testScene()
+
+	for _, colourSpace := range []ColourSpace{ColourSpaceLinear, ColourSpaceGamma2} {
+		opts := testOptions()
+		opts.BounceLayers, opts.OutputColourSpace = true, colourSpace
+		pixels, _, layers := New(opts).renderPasses(world)
+
+		// Before the gamma correction, the layers add up to the full render.
+		for idx, pixel := range pixels {
+			sum := colourSpace.decode(layers.direct[idx]).Add(colourSpace.decode(layers.indirect[idx]))
+			if want := colourSpace.decode(pixel); !sum.ApproxEqual(want, 1e-9) {
+				t.Fatalf("colour space %v, pixel %d: expected the layers to add up to %v, got %v",
+					colourSpace, idx, want, sum)
+			}
+		}
+	}
+
+	// The layers split the same image that the iterative tracer renders without them.
+	opts := testOptions()
+	opts.Iterative = true
+	want, _, _ := New(opts).renderPasses(world)
+	opts.BounceLayers = true
+	got, _, layers := New(opts).renderPasses(world)
+	for idx := range want {
+		if !got[idx].ApproxEqual(want[idx], 0) {
+			t.Fatalf("pixel %d: expected the bounce layers to leave the render %v, got %v", idx, want[idx], got[idx])
+		}
+	}
+
+	// Some light reaches the camera only after several bounces, like the reflections in the glass.
+	var indirect float64
+	for _, colour := range layers.indirect {
+		indirect += colour.Luminance()
+	}
+	if indirect == 0 {
+		t.Errorf("expected some indirect light")
+	}
+}
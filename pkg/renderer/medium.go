@@ -0,0 +1,46 @@
+package renderer
+
+import (
+	"math"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// Medium is a participating medium, like haze or fog, that fills the whole scene.
+//
+// Light travelling through it is attenuated exponentially with the distance, and replaced by the
+// colour of the medium. So, distant objects look washed out (aerial perspective).
+// The rays that do not hit anything travel infinitely far, so the background is fully replaced.
+//
+// To know more, visit-
+// https://en.wikipedia.org/wiki/Beer%E2%80%93Lambert_law
+type Medium struct {
+	// Density of the medium. Light loses about 63% of its intensity over a distance of 1 / Density.
+	Density float64
+	// Colour of the medium, which distant objects fade into.
+	Colour *utils.Colour
+}
+
+// NewMedium returns a new Medium instance.
+func NewMedium(density float64, colour *utils.Colour) *Medium {
+	return &Medium{Density: density, Colour: colour}
+}
+
+// transmittance returns the fraction of light that passes through the given distance of the medium.
+// It is 1 for a nil medium.
+func (m *Medium) transmittance(distance float64) float64 {
+	if m == nil || m.Density <= 0 {
+		return 1
+	}
+	return math.Exp(-m.Density * distance)
+}
+
+// apply returns the given colour, as seen through the given distance of the medium.
+func (m *Medium) apply(colour *utils.Colour, distance float64) *utils.Colour {
+	transmittance := m.transmittance(distance)
+	if transmittance == 1 {
+		return colour
+	}
+
+	return colour.Scale(transmittance).Add(m.Colour.Scale(1 - transmittance))
+}
@@ -0,0 +1,69 @@
+package renderer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/shapes"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestRenderer_GlobalMedium(t *testing.T) {
+	// Two red lights, which do not scatter, so the colour of a ray is only their light seen through the haze.
+	red := utils.NewColour(1, 0, 0)
+	near := shapes.NewSphere(utils.NewVec3(-1, 0, -2), 0.5, mats.NewDiffuseLight(red))
+	far := shapes.NewSphere(utils.NewVec3(1, 0, -10), 0.5, mats.NewDiffuseLight(red))
+	world := shapes.NewGroup(near, far)
+
+	opts := testOptions()
+	opts.GlobalMedium = NewMedium(0.2, utils.NewColour(1, 1, 1))
+	rend := New(opts)
+
+	// The colour of a ray from the given x coordinate, travelling down the -Z axis, with both tracers.
+	trace := func(x float64) (recursive, iterative *utils.Colour) {
+		ray := utils.NewRay(utils.NewVec3(x, 0, 0), utils.NewVec3(0, 0, -1))
+		recursive = rend.traceRay(ray, world, rend.hitInterval(), opts.MaxDiffusionDepth, rend.newBounceBudget(), 0,
+			&pixelContext{rng: random.New(1)})
+		iterative, _ = rend.traceRayIterative(ray, world, opts.MaxDiffusionDepth, &pixelContext{rng: random.New(1)})
+		return recursive, iterative
+	}
+
+	tests := []struct {
+		name string
+		x    float64
+		want *utils.Colour
+	}{
+		// The lights are hit at the distances of 1.5 and 9.5, and fade into the white haze by Beer-Lambert's law.
+		{name: "near", x: -1, want: utils.NewColour(1, 1-math.Exp(-0.3), 1-math.Exp(-0.3))},
+		{name: "far", x: 1, want: utils.NewColour(1, 1-math.Exp(-1.9), 1-math.Exp(-1.9))},
+		// The background is infinitely far, so only the haze is left.
+		{name: "background", x: 5, want: utils.NewColour(1, 1, 1)},
+	}
+
+	for _, test := range tests {
+		recursive, iterative := trace(test.x)
+		if !recursive.ApproxEqual(test.want, 1e-9) {
+			t.Errorf("%s: expected %v from the recursive tracer, got %v", test.name, test.want, recursive)
+		}
+		if !iterative.ApproxEqual(test.want, 1e-9) {
+			t.Errorf("%s: expected %v from the iterative tracer, got %v", test.name, test.want, iterative)
+		}
+	}
+
+	// The far light is washed out more than the near one, while both stay red without the medium.
+	nearColour, _ := trace(-1)
+	farColour, _ := trace(1)
+	if farColour.G <= nearColour.G {
+		t.Errorf("expected the far light to be washed out more, got %v near and %v far", nearColour, farColour)
+	}
+
+	opts.GlobalMedium = nil
+	rend = New(opts)
+	for _, x := range []float64{-1, 1} {
+		if recursive, _ := trace(x); !recursive.ApproxEqual(red, 0) {
+			t.Errorf("expected the light at %v to stay red without the medium, got %v", x, recursive)
+		}
+	}
+}
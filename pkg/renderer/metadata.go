@@ -0,0 +1,82 @@
+package renderer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// metadataEntry is a key-value pair of the metadata embedded into the output file.
+type metadataEntry struct {
+	key, value string
+}
+
+// metadata returns the render settings and the given render time as metadata entries.
+func (r *Renderer) metadata(renderTime time.Duration) []metadataEntry {
+	return []metadataEntry{
+		{key: "Software", value: "lightshow"},
+		{key: "Width", value: strconv.Itoa(int(r.opts.ImageWidth))},
+		{key: "Height", value: strconv.Itoa(int(r.opts.ImageHeight))},
+		{key: "SamplesPerPixel", value: strconv.Itoa(r.opts.SamplesPerPixel)},
+		{key: "MaxDiffusionDepth", value: strconv.Itoa(r.opts.MaxDiffusionDepth)},
+		{key: "Seed", value: strconv.FormatUint(r.opts.Seed, 10)},
+		{key: "RenderTime", value: renderTime.Round(time.Millisecond).String()},
+	}
+}
+
+// withPNGText returns the given encoded PNG with the metadata inserted as tEXt chunks.
+// To know more about the PNG chunks, visit-
+// https://www.w3.org/TR/png/#11tEXt
+func withPNGText(encoded []byte, metadata []metadataEntry) []byte {
+	if len(metadata) == 0 {
+		return encoded
+	}
+
+	// The chunks are placed right after the IHDR chunk, which follows the 8-byte signature.
+	// The IHDR chunk is 25 bytes long, including its length, type and CRC.
+	const headerEnd = 8 + 25
+
+	var result bytes.Buffer
+	result.Write(encoded[:headerEnd])
+
+	for _, entry := range metadata {
+		// A tEXt chunk is the keyword and the text, separated by a null byte.
+		data := append([]byte("tEXt"+entry.key+"\x00"), entry.value...)
+
+		// The length excludes the type, while the CRC includes it.
+		_ = binary.Write(&result, binary.BigEndian, uint32(len(data)-4))
+		result.Write(data)
+		_ = binary.Write(&result, binary.BigEndian, crc32.ChecksumIEEE(data))
+	}
+
+	result.Write(encoded[headerEnd:])
+	return result.Bytes()
+}
+
+// withJPEGComment returns the given encoded JPEG with the metadata inserted as a COM segment,
+// with one "key: value" line per entry.
+func withJPEGComment(encoded []byte, metadata []metadataEntry) []byte {
+	if len(metadata) == 0 {
+		return encoded
+	}
+
+	lines := make([]string, len(metadata))
+	for i, entry := range metadata {
+		lines[i] = entry.key + ": " + entry.value
+	}
+	comment := strings.Join(lines, "\n")
+
+	// The segment is placed right after the 2-byte SOI marker.
+	// Its length includes the 2 bytes of the length itself, but not the marker.
+	var result bytes.Buffer
+	result.Write(encoded[:2])
+	result.Write([]byte{0xFF, 0xFE})
+	_ = binary.Write(&result, binary.BigEndian, uint16(len(comment)+2))
+	result.WriteString(comment)
+	result.Write(encoded[2:])
+
+	return result.Bytes()
+}
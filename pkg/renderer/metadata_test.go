@@ -0,0 +1,81 @@
+package renderer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncodeImage_Metadata(t *testing.T) {
+	const width, height = 8, 6
+	img := buildImage(testPixels(width, height), width, height, 8)
+
+	opts := testOptions()
+	metadata := New(opts).metadata(1500 * time.Millisecond)
+	want := map[string]string{"Software": "lightshow", "Width": "32", "Height": "24", "Seed": "42", "RenderTime": "1.5s"}
+
+	// The PNG carries a valid tEXt chunk for every entry, and still decodes.
+	pngPath := filepath.Join(t.TempDir(), "image.png")
+	if err := encodeImage(img, pngPath, &encodeOptions{metadata: metadata}); err != nil {
+		t.Fatalf("failed to encode the PNG: %v", err)
+	}
+	encoded, err := os.ReadFile(pngPath)
+	if err != nil {
+		t.Fatalf("failed to read the PNG: %v", err)
+	}
+	if _, err := png.Decode(bytes.NewReader(encoded)); err != nil {
+		t.Fatalf("failed to decode the PNG with the metadata: %v", err)
+	}
+
+	found := map[string]string{}
+	// The chunks follow the 8-byte signature. Each is its length, type, data and CRC.
+	for offset := 8; offset+12 <= len(encoded); {
+		length := int(binary.BigEndian.Uint32(encoded[offset:]))
+		chunk := encoded[offset+4 : offset+8+length]
+		if crc := binary.BigEndian.Uint32(encoded[offset+8+length:]); crc != crc32.ChecksumIEEE(chunk) {
+			t.Errorf("expected a valid CRC for the %q chunk", chunk[:4])
+		}
+		if string(chunk[:4]) == "tEXt" {
+			key, value, _ := strings.Cut(string(chunk[4:]), "\x00")
+			found[key] = value
+		}
+		offset += 12 + length
+	}
+	for key, value := range want {
+		if found[key] != value {
+			t.Errorf("expected the PNG to have the %s %q, got %q", key, value, found[key])
+		}
+	}
+
+	// The JPEG carries the entries in a comment, and still decodes.
+	jpegPath := filepath.Join(t.TempDir(), "image.jpg")
+	if err := encodeImage(img, jpegPath, &encodeOptions{metadata: metadata, jpegQuality: 90}); err != nil {
+		t.Fatalf("failed to encode the JPEG: %v", err)
+	}
+	encoded, err = os.ReadFile(jpegPath)
+	if err != nil {
+		t.Fatalf("failed to read the JPEG: %v", err)
+	}
+	if _, err := jpeg.Decode(bytes.NewReader(encoded)); err != nil {
+		t.Fatalf("failed to decode the JPEG with the metadata: %v", err)
+	}
+
+	// The COM segment is right after the SOI marker, and its length includes the length bytes.
+	if !bytes.Equal(encoded[2:4], []byte{0xFF, 0xFE}) {
+		t.Fatalf("expected a comment segment after the SOI marker, got %X", encoded[2:4])
+	}
+	length := int(binary.BigEndian.Uint16(encoded[4:]))
+	comment := string(encoded[6 : 4+length])
+	for key, value := range want {
+		if line := key + ": " + value; !strings.Contains("\n"+comment+"\n", "\n"+line+"\n") {
+			t.Errorf("expected the JPEG comment to have the line %q, got %q", line, comment)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package renderer
+
+import (
+	"math"
+
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// misMatte returns the material of the given point-of-hit if it should sample the lights with MIS,
+// which requires the UseMIS option, some Lights, and a mats.Matte material.
+func (r *Renderer) misMatte(hitInfo *mats.RayHit) (*mats.Matte, bool) {
+	if !r.opts.UseMIS || len(r.opts.Lights) == 0 {
+		return nil, false
+	}
+
+	matte, ok := hitInfo.Mat.(*mats.Matte)
+	return matte, ok
+}
+
+// sampleLight returns the light that reaches the point-of-hit straight from a point on a randomly chosen light,
+// and is reflected by the matte toward the ray's origin. It is weighted with MIS against the scattered rays,
+// which also find the lights. It is black if the point on the light is hidden or faces away.
+func (r *Renderer) sampleLight(
+	ray *utils.Ray, hitInfo *mats.RayHit, matte *mats.Matte, world shape, ctx *pixelContext,
+) *utils.Colour {
+	radiance, dir, lightPDF := r.sampleLightRadiance(ray, hitInfo, matte, world, ctx)
+	if lightPDF <= 0 {
+		return radiance
+	}
+
+	weight := powerHeuristic(lightPDF, matte.PDF(hitInfo, dir))
+	return radiance.Scale(weight / lightPDF)
+}
+
+// sampleLightRadiance picks a random point on a randomly chosen light, and returns the light that reaches
+// the point-of-hit from it and is reflected by the matte toward the ray's origin, along with the direction
+// toward it and the probability density (with respect to solid angle) of choosing it. The density is zero if
+// the point cannot contribute any light, in which case the radiance is black.
+func (r *Renderer) sampleLightRadiance(
+	ray *utils.Ray, hitInfo *mats.RayHit, matte *mats.Matte, world shape, ctx *pixelContext,
+) (radiance *utils.Colour, dir *utils.Vec3, pdf float64) {
+	black := utils.NewColour(0, 0, 0)
+
+	lights := r.opts.Lights
+	light := lights[int(math.Min(ctx.rng.Float()*float64(len(lights)), float64(len(lights)-1)))]
+	point, _, pdf := light.SamplePoint(hitInfo.Point, ctx.rng)
+	// Every light is chosen with the same probability.
+	pdf /= float64(len(lights))
+
+	toLight := point.Sub(hitInfo.Point)
+	distance := toLight.Mag()
+	dir = toLight.Div(distance)
+	cosine := hitInfo.Normal.Dot(dir)
+	if pdf <= 0 || cosine <= 0 {
+		return black, dir, 0
+	}
+
+	// Cast a shadow ray toward the point. Anything hit before it means that the point is hidden.
+	ctx.rays++
+	shadowRay := utils.NewRay(hitInfo.Point, dir)
+	shadowRay.Time = ray.Time
+	lightHit, isHit := world.Hit(shadowRay, r.surfaceInterval(hitInfo))
+	if !isHit || lightHit.Distance < distance*(1-1e-6) {
+		return black, dir, 0
+	}
+
+	// The Lambertian reflectance is the albedo / π, applied according to Lambert's cosine law.
+	reflectance := matte.DiffuseAlbedo(hitInfo).Scale(cosine / math.Pi)
+	emitted := emission(shadowRay, lightHit).Scale(r.opts.GlobalMedium.transmittance(lightHit.Distance))
+	return emitted.Attenuate(reflectance), dir, pdf
+}
+
+// lightPDF returns the probability density (with respect to solid angle) with which sampleLight would choose
+// the direction of the given ray, from its origin.
+func (r *Renderer) lightPDF(ray *utils.Ray) float64 {
+	var sum float64
+	for _, light := range r.opts.Lights {
+		sum += light.PDFValue(ray.Origin, ray.Dir)
+	}
+	return sum / float64(len(r.opts.Lights))
+}
+
+// powerHeuristic returns the MIS weight of a sample chosen with the given density,
+// when the other technique would have chosen it with the other density.
+// To know more, visit-
+// https://www.pbr-book.org/3ed-2018/Monte_Carlo_Integration/Importance_Sampling#MultipleImportanceSampling
+func powerHeuristic(pdf, otherPDF float64) float64 {
+	pdfSq, otherSq := pdf*pdf, otherPDF*otherPDF
+	if pdfSq+otherSq == 0 {
+		return 0
+	}
+	return pdfSq / (pdfSq + otherSq)
+}
@@ -0,0 +1,76 @@
+package renderer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/shapes"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestRenderer_UseMIS(t *testing.T) {
+	// A floor under a large area light, which is close to it and faces down.
+	light := shapes.NewAreaLight(
+		utils.NewVec3(-1, 0.6, -1), utils.NewVec3(2, 0, 0), utils.NewVec3(0, 0, 2), utils.NewColour(4, 4, 4))
+	floor := shapes.NewQuad(utils.NewVec3(-5, 0, 5), utils.NewVec3(10, 0, 0), utils.NewVec3(0, 0, -10),
+		mats.NewMatte(utils.NewColour(0.8, 0.8, 0.8)))
+	world := shapes.NewGroup(light, floor)
+
+	opts := testOptions()
+	opts.Background = NewSolidBackground(utils.NewColour(0, 0, 0))
+	opts.Lights = []shapes.Light{light}
+	// The floor can only be lit directly.
+	opts.MaxDiffusionDepth = 2
+
+	// A ray that passes under the edge of the light, and hits the floor near it.
+	ray := utils.NewRay(utils.NewVec3(3, 1, 3), utils.NewVec3(0.8, 0, 0.8).Sub(utils.NewVec3(3, 1, 3)))
+	floorHit, _ := floor.Hit(ray, utils.NewInterval(0, math.MaxFloat64))
+
+	// The mean and the variance of the luminance of the given estimator of the light on the floor.
+	const samples = 20000
+	estimate := func(estimator func(ctx *pixelContext) *utils.Colour) (mean, variance float64) {
+		ctx := &pixelContext{rng: random.New(9)}
+		var sum, sumSq float64
+		for i := 0; i < samples; i++ {
+			luminance := estimator(ctx).Luminance()
+			sum, sumSq = sum+luminance, sumSq+luminance*luminance
+		}
+		mean = sum / samples
+		return mean, sumSq/samples - mean*mean
+	}
+
+	trace := func(useMIS bool) func(ctx *pixelContext) *utils.Colour {
+		opts := *opts
+		opts.UseMIS = useMIS
+		rend := New(&opts)
+		return func(ctx *pixelContext) *utils.Colour {
+			return rend.traceRay(ray, world, rend.hitInterval(), 2, rend.newBounceBudget(), 0, ctx)
+		}
+	}
+
+	rend := New(opts)
+	lightOnly := func(ctx *pixelContext) *utils.Colour {
+		radiance, _, pdf := rend.sampleLightRadiance(ray, floorHit, floor.Mat.(*mats.Matte), world, ctx)
+		if pdf <= 0 {
+			return radiance
+		}
+		return radiance.Scale(1 / pdf)
+	}
+
+	misMean, misVariance := estimate(trace(true))
+	scatterMean, scatterVariance := estimate(trace(false))
+	lightMean, lightVariance := estimate(lightOnly)
+
+	// All the techniques converge to the same light, but MIS is the least noisy.
+	for _, mean := range []float64{scatterMean, lightMean} {
+		if math.Abs(misMean-mean) > 0.03*mean {
+			t.Errorf("expected the MIS mean %v to match the other techniques, got %v", misMean, mean)
+		}
+	}
+	if misVariance >= scatterVariance || misVariance >= lightVariance {
+		t.Errorf("expected MIS to have the lowest variance, got %v against %v with scattering and %v with lights",
+			misVariance, scatterVariance, lightVariance)
+	}
+}
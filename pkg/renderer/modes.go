@@ -0,0 +1,71 @@
+package renderer
+
+import (
+	"math"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// Mode determines what the renderer shows.
+// Modes other than ModeBeauty are meant for debugging, and only ModeBeauty and ModeFocusPeaking
+// are gamma corrected.
+type Mode int
+
+const (
+	// ModeBeauty renders the scene normally.
+	ModeBeauty Mode = iota
+	// ModeNormals colours every hit by its outward surface normal, mapped to RGB using 0.5 * (normal + 1).
+	// Materials and reflections are ignored. It reveals flipped or discontinuous normals.
+	ModeNormals
+	// ModeHeatMap colours every pixel by its cost, which is the average number of rays traced per sample.
+	// The cheapest pixels are blue and the costliest ones are red. It reveals where the render spends its time.
+	ModeHeatMap
+	// ModeFocusPeaking highlights the pixels that are in focus, over a dimmed render of the scene, like the
+	// focus peaking of cameras. A pixel is in focus if the depth of the surface seen through its center is
+	// within the FocusTolerance of the camera's focus distance. It helps in tuning the depth of field.
+	ModeFocusPeaking
+)
+
+// isGammaCorrected tells whether the output of the mode is gamma corrected.
+func (m Mode) isGammaCorrected() bool {
+	return m == ModeBeauty || m == ModeFocusPeaking
+}
+
+// peakFocus returns the colour of the given pixel in the ModeFocusPeaking mode, given its rendered colour.
+func (r *Renderer) peakFocus(x, y float64, world shape, colour *utils.Colour) *utils.Colour {
+	// Bring x and y in the [0, 1) interval, with the ray going through the pixel center.
+	x = (x + 0.5) / (r.opts.ImageWidth - 1)
+	y = (y + 0.5) / (r.opts.ImageHeight - 1)
+
+	focusDistance := r.opts.Camera.FocusDistance()
+	tolerance := r.opts.FocusTolerance
+	if tolerance <= 0 {
+		tolerance = 0.02 * focusDistance
+	}
+
+	r.rays.Add(1)
+	hitInfo, isHit := world.Hit(r.opts.Camera.CastCenterRay(x, y), r.hitInterval())
+	if isHit && math.Abs(r.opts.Camera.Depth(hitInfo.Point)-focusDistance) <= tolerance {
+		return utils.NewColour(1, 0, 1)
+	}
+
+	// The pixels that are out of focus are dimmed, so that the highlights stand out.
+	return colour.Scale(0.4)
+}
+
+// shadeNormal returns the colour of the given ray in the ModeNormals mode.
+// Rays that do not hit anything are black.
+func (r *Renderer) shadeNormal(ray *utils.Ray, world shape) *utils.Colour {
+	hitInfo, isHit := world.Hit(ray, r.hitInterval())
+	if !isHit {
+		return utils.NewColour(0, 0, 0)
+	}
+
+	// The normal in the RayHit always faces the ray. It is flipped back for inside hits.
+	normal := hitInfo.Normal
+	if !hitInfo.IsRayOutside {
+		normal = normal.Mul(-1)
+	}
+
+	return normal.Add(utils.NewVec3(1, 1, 1)).Mul(0.5).ToColour()
+}
@@ -0,0 +1,112 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/camera"
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/shapes"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// straightOptions returns the testOptions, with the camera looking straight down the -Z axis at (0, 0, -1).
+func straightOptions() *Options {
+	opts := testOptions()
+	opts.Camera = camera.New(&camera.Options{
+		LookFrom:            utils.NewVec3(0, 0, 2),
+		LookAt:              utils.NewVec3(0, 0, -1),
+		Up:                  utils.NewVec3(0, 1, 0),
+		AspectRatio:         4.0 / 3,
+		FieldOfViewVertical: 40,
+		AutoFocus:           true,
+	})
+	return opts
+}
+
+func TestRenderer_ModeNormals(t *testing.T) {
+	sphere := shapes.NewSphere(utils.NewVec3(0, 0, -1), 0.5, mats.NewMetallic(utils.NewColour(0.8, 0.6, 0.2), 0))
+	world := shapes.NewGroup(sphere)
+
+	opts := straightOptions()
+	opts.Mode, opts.DisableJitter, opts.SamplesPerPixel = ModeNormals, true, 1
+	rend := New(opts)
+	pixels, _, _ := rend.renderPasses(world)
+
+	// The center of the sphere faces the camera, so its normal (0, 0, 1) is light blue, whatever the material.
+	// The pixel is half a pixel away from the exact center, so its normal is slightly tilted.
+	width, height := int(opts.ImageWidth), int(opts.ImageHeight)
+	if got := pixels[height/2*width+width/2]; !got.ApproxEqual(utils.NewColour(0.5, 0.5, 1), 0.1) {
+		t.Errorf("expected the center of the sphere to be light blue, got %v", got)
+	}
+	// The sky is black.
+	if got := pixels[0]; !got.ApproxEqual(utils.NewColour(0, 0, 0), 0) {
+		t.Errorf("expected the sky to be black, got %v", got)
+	}
+
+	tests := []struct {
+		name string
+		ray  *utils.Ray
+		want *utils.Colour
+	}{
+		// Near the edges, the normals point sideways.
+		{name: "left edge", ray: utils.NewRay(utils.NewVec3(-0.499, 0, 2), utils.NewVec3(0, 0, -1)),
+			want: utils.NewColour(0, 0.5, 0.5)},
+		{name: "right edge", ray: utils.NewRay(utils.NewVec3(0.499, 0, 2), utils.NewVec3(0, 0, -1)),
+			want: utils.NewColour(1, 0.5, 0.5)},
+		{name: "top edge", ray: utils.NewRay(utils.NewVec3(0, 0.499, 2), utils.NewVec3(0, 0, -1)),
+			want: utils.NewColour(0.5, 1, 0.5)},
+		// From inside, the outward normal is shown, although the hit faces the other way.
+		{name: "inside", ray: utils.NewRay(utils.NewVec3(0, 0, -1), utils.NewVec3(0, 0, -1)),
+			want: utils.NewColour(0.5, 0.5, 0)},
+	}
+
+	for _, test := range tests {
+		if got := rend.shadeNormal(test.ray, world); !got.ApproxEqual(test.want, 0.05) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestRenderer_ModeFocusPeaking(t *testing.T) {
+	// The camera focuses at the depth of 3, which is the plane z = -1. In the top half, the left wall lies on it,
+	// while the right wall is far behind. In the bottom half, the wall lies just behind it.
+	mat := mats.NewMatte(utils.NewColour(0.5, 0.5, 0.5))
+	world := shapes.NewGroup(
+		shapes.NewQuad(utils.NewVec3(-5, 0, -1), utils.NewVec3(5, 0, 0), utils.NewVec3(0, 5, 0), mat),
+		shapes.NewQuad(utils.NewVec3(0, 0, -20), utils.NewVec3(50, 0, 0), utils.NewVec3(0, 50, 0), mat),
+		shapes.NewQuad(utils.NewVec3(-5, -5, -1.1), utils.NewVec3(10, 0, 0), utils.NewVec3(0, 5, 0), mat),
+	)
+
+	width, height := 32, 24
+	topLeft, topRight, bottom := height/4*width+width/4, height/4*width+width*3/4, height*3/4*width+width/2
+	magenta := utils.NewColour(1, 0, 1)
+	beauty, _, _ := New(straightOptions()).renderPasses(world)
+
+	tests := []struct {
+		name      string
+		tolerance float64
+		// Whether the top-left, top-right and bottom pixels are highlighted.
+		want [3]bool
+	}{
+		// The default tolerance of 2% of the focus distance misses the bottom wall, 0.1 behind the plane.
+		{name: "default tolerance", want: [3]bool{true, false, false}},
+		{name: "wide tolerance", tolerance: 0.2, want: [3]bool{true, false, true}},
+	}
+
+	for _, test := range tests {
+		opts := straightOptions()
+		opts.Mode, opts.FocusTolerance = ModeFocusPeaking, test.tolerance
+		pixels, _, _ := New(opts).renderPasses(world)
+
+		for idx, pixel := range [3]int{topLeft, topRight, bottom} {
+			if got := pixels[pixel]; got.ApproxEqual(magenta, 1e-9) != test.want[idx] {
+				t.Errorf("%s: expected the highlight of the pixel %d to be %v, got %v",
+					test.name, pixel, test.want[idx], got)
+			}
+			// The pixels out of focus are dimmed.
+			if got := pixels[pixel]; !test.want[idx] && got.Luminance() >= beauty[pixel].Luminance() {
+				t.Errorf("%s: expected the pixel %d to be dimmed from %v, got %v", test.name, pixel, beauty[pixel], got)
+			}
+		}
+	}
+}
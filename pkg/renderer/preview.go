@@ -0,0 +1,27 @@
+package renderer
+
+import (
+	"image"
+	"math"
+)
+
+// previewed returns a copy of the renderer that renders at PreviewScale times the resolution,
+// with proportionally fewer samples per pixel.
+func (r *Renderer) previewed() *Renderer {
+	scale := r.opts.PreviewScale
+
+	opts := *r.opts
+	opts.ImageWidth = math.Max(math.Round(r.opts.ImageWidth*scale), 1)
+	opts.ImageHeight = math.Max(math.Round(r.opts.ImageHeight*scale), 1)
+	opts.SamplesPerPixel = int(math.Max(math.Round(float64(r.opts.SamplesPerPixel)*scale), 1))
+	// The region is rounded like the image size, so that a full-frame region stays full-frame.
+	scaled := func(coord int) int { return int(math.Round(float64(coord) * scale)) }
+	opts.Region = image.Rect(
+		scaled(r.opts.Region.Min.X), scaled(r.opts.Region.Min.Y),
+		scaled(r.opts.Region.Max.X), scaled(r.opts.Region.Max.Y),
+	)
+	opts.PreviewScale = 0
+
+	// The ray counter is shared, so that the rays of the preview are counted.
+	return &Renderer{opts: &opts, rays: r.rays, samples: r.samples}
+}
@@ -0,0 +1,43 @@
+package renderer_test
+
+import (
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/renderer"
+)
+
+func TestRenderer_PreviewScale(t *testing.T) {
+	tests := []struct {
+		name  string
+		scale float64
+		// Expected dimensions of the output, and the samples per pixel.
+		width, height, samplesPerPixel int
+	}{
+		{name: "half", scale: 0.5, width: 15, height: 10, samplesPerPixel: 2},
+		// The samples per pixel never drop below one.
+		{name: "tenth", scale: 0.1, width: 3, height: 2, samplesPerPixel: 1},
+		// The dimensions never drop below one pixel.
+		{name: "tiny", scale: 0.01, width: 1, height: 1, samplesPerPixel: 1},
+		// Values outside (0, 1) render at full quality.
+		{name: "disabled", scale: 0, width: 30, height: 20, samplesPerPixel: 4},
+		{name: "full", scale: 1, width: 30, height: 20, samplesPerPixel: 4},
+	}
+
+	for _, test := range tests {
+		world, opts := tileTestScene(t)
+		opts.PreviewScale = test.scale
+
+		rend := renderer.New(opts)
+		if err := rend.Render(world); err != nil {
+			t.Fatalf("%s: failed to render: %v", test.name, err)
+		}
+
+		if bounds := decodePNG(t, opts.OutputFile).Bounds(); bounds.Dx() != test.width || bounds.Dy() != test.height {
+			t.Errorf("%s: expected a %dx%d image, got %dx%d", test.name, test.width, test.height,
+				bounds.Dx(), bounds.Dy())
+		}
+		if got, want := rend.Stats().SamplesTraced, int64(test.width*test.height*test.samplesPerPixel); got != want {
+			t.Errorf("%s: expected %d samples traced, got %d", test.name, want, got)
+		}
+	}
+}
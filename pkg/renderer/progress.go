@@ -0,0 +1,120 @@
+package renderer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// progressInterval is the time between two progress updates.
+	progressInterval = 200 * time.Millisecond
+	// progressBarWidth is the number of characters inside the progress bar.
+	progressBarWidth = 40
+	// progressSmoothing is the weight of the latest rate in the smoothed rate.
+	// Lower values give a steadier ETA, but it adapts slower to changes.
+	progressSmoothing = 0.1
+)
+
+// progressBar tracks the progress of a render and formats it as a bar with an ETA.
+type progressBar struct {
+	// total is the amount of work to be done.
+	total int
+
+	// lastTime and lastDone are from the previous update.
+	lastTime time.Time
+	lastDone int
+	// rate is the smoothed amount of work done per second.
+	rate float64
+}
+
+// newProgressBar returns a new progressBar for the given amount of work, starting at the given time.
+func newProgressBar(total int, start time.Time) *progressBar {
+	return &progressBar{total: total, lastTime: start}
+}
+
+// update records the amount of work done until the given time and returns the formatted progress.
+// For example: "[===>      ] 42% ETA 00:01:23".
+func (p *progressBar) update(done int, now time.Time) string {
+	// Update the smoothed rate using the work done since the last update.
+	if elapsed := now.Sub(p.lastTime).Seconds(); elapsed > 0 {
+		latestRate := float64(done-p.lastDone) / elapsed
+		if p.rate == 0 {
+			p.rate = latestRate
+		} else {
+			p.rate = progressSmoothing*latestRate + (1-progressSmoothing)*p.rate
+		}
+		p.lastTime, p.lastDone = now, done
+	}
+
+	fraction := 1.0
+	if p.total > 0 {
+		fraction = float64(done) / float64(p.total)
+	}
+
+	// The ETA is unknown until some work is done.
+	eta := "--:--:--"
+	if p.rate > 0 {
+		eta = formatDuration(time.Duration(float64(p.total-done) / p.rate * float64(time.Second)))
+	}
+
+	return fmt.Sprintf("[%s] %3.0f%% ETA %s", bar(fraction), fraction*100, eta)
+}
+
+// bar returns the bar part of the progress for the given completion fraction.
+func bar(fraction float64) string {
+	filled := int(fraction * progressBarWidth)
+	if filled >= progressBarWidth {
+		return strings.Repeat("=", progressBarWidth)
+	}
+
+	return strings.Repeat("=", filled) + ">" + strings.Repeat(" ", progressBarWidth-filled-1)
+}
+
+// formatDuration formats the given duration as "hh:mm:ss".
+func formatDuration(duration time.Duration) string {
+	seconds := int(duration.Round(time.Second).Seconds())
+	return fmt.Sprintf("%02d:%02d:%02d", seconds/3600, (seconds/60)%60, seconds%60)
+}
+
+// startProgress periodically writes the progress of the render to the configured writer,
+// until the returned function is called. The returned function waits for the final write.
+//
+// The done argument is the amount of work done so far and is expected to be updated concurrently.
+func (r *Renderer) startProgress(done *atomic.Int64, total int) (stop func()) {
+	if r.opts.Progress == nil {
+		return func() {}
+	}
+
+	stopChan, doneChan := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(doneChan)
+		reportProgress(r.opts.Progress, done, total, stopChan)
+	}()
+
+	return func() {
+		close(stopChan)
+		<-doneChan
+	}
+}
+
+// reportProgress writes the progress at every progressInterval until the stop channel is closed.
+// Every write overwrites the previous one using a carriage return.
+func reportProgress(writer io.Writer, done *atomic.Int64, total int, stop <-chan struct{}) {
+	progress := newProgressBar(total, time.Now())
+
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			_, _ = fmt.Fprintf(writer, "\r%s", progress.update(int(done.Load()), now))
+		case <-stop:
+			_, _ = fmt.Fprintf(writer, "\r%s\n", progress.update(int(done.Load()), time.Now()))
+			return
+		}
+	}
+}
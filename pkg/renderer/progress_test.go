@@ -0,0 +1,62 @@
+package renderer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProgressBar_ETA(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	progress := newProgressBar(10000, start)
+
+	// The ETA is unknown before any work is done.
+	if got := progress.update(0, start); !strings.HasSuffix(got, "ETA --:--:--") {
+		t.Errorf("expected an unknown ETA at the start, got %q", got)
+	}
+
+	// The ETA of the given formatted progress.
+	eta := func(formatted string) time.Duration {
+		var hours, minutes, seconds int
+		_, etaPart, _ := strings.Cut(formatted, "ETA ")
+		if _, err := fmt.Sscanf(etaPart, "%d:%d:%d", &hours, &minutes, &seconds); err != nil {
+			t.Fatalf("failed to parse the ETA of %q: %v", formatted, err)
+		}
+		return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	}
+
+	// A steady rate of 10 units per second gives the exact ETA right away.
+	now, done := start, 0
+	for i := 0; i < 10; i++ {
+		now, done = now.Add(time.Second), done+10
+		if got, want := eta(progress.update(done, now)), time.Duration(10000-done)/10*time.Second; got != want {
+			t.Fatalf("after %d units: expected the ETA %v, got %v", done, want, got)
+		}
+	}
+
+	// After the rate doubles, the ETA converges to the new one, without jumping to it at once.
+	var previousError time.Duration
+	for i := 0; i < 60; i++ {
+		now, done = now.Add(time.Second), done+20
+		got, want := eta(progress.update(done, now)), time.Duration(10000-done)/20*time.Second
+
+		errorNow := got - want
+		if i == 0 && errorNow < 10*time.Second {
+			t.Errorf("expected the ETA to adapt gradually, got %v against the new %v right away", got, want)
+		}
+		if i > 0 && errorNow > previousError {
+			t.Errorf("after %d units: expected the ETA error to shrink, got %v after %v", done, errorNow, previousError)
+		}
+		previousError = errorNow
+	}
+	if previousError > 2*time.Second {
+		t.Errorf("expected the ETA to converge to the new rate, got an error of %v", previousError)
+	}
+
+	// At the end, nothing is left.
+	if got := progress.update(10000, now.Add(time.Second)); !strings.HasPrefix(got, "[====") ||
+		!strings.HasSuffix(got, "100% ETA 00:00:00") {
+		t.Errorf("expected a full bar at the end, got %q", got)
+	}
+}
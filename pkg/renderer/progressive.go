@@ -0,0 +1,53 @@
+package renderer
+
+import (
+	"image"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// renderProgressive renders the pixels of the given region in passes of 1, 2, 4, 8... samples per pixel,
+// until SamplesPerPixel are rendered. After every pass but the last one, the average of all samples so far
+// is encoded into the OutputFile, so a usable image is available almost immediately and keeps improving.
+//
+// The final pixels are stored like the non-progressive render, and are left for the caller to encode.
+// Since the final encoding would fail the same way, errors in the intermediate encodings are ignored.
+func (r *Renderer) renderProgressive(
+	world shape, region image.Rectangle, pixels []*utils.Colour, layers *bounceLayers, heat *heatMap,
+) {
+	width, height := int(r.opts.ImageWidth), int(r.opts.ImageHeight)
+	encodeOpts := &encodeOptions{jpegQuality: r.opts.JPEGQuality}
+
+	// Samples of all pixels so far.
+	accumulated := make([]*pixelSamples, width*height)
+
+	for first, count := 0, 1; first < r.opts.SamplesPerPixel; first, count = first+count, count*2 {
+		// The last pass may be smaller, to not exceed the samples per pixel.
+		if first+count > r.opts.SamplesPerPixel {
+			count = r.opts.SamplesPerPixel - first
+		}
+
+		r.forEachPixel(region, func(x, y int) {
+			idx := y*width + x
+			samples := r.samplePixel(float64(x), r.flipY(y), world, first, count)
+			if accumulated[idx] != nil {
+				samples = accumulated[idx].add(samples)
+			}
+
+			accumulated[idx] = samples
+			pixels[idx] = r.resolvePixel(samples.sum, samples.weight)
+		})
+
+		if first+count < r.opts.SamplesPerPixel {
+			_ = encodeImage(buildImage(pixels, width, height, r.opts.BitDepth), r.opts.OutputFile, encodeOpts)
+		}
+	}
+
+	// Store the other outputs, now that all samples are rendered.
+	for y := region.Min.Y; y < region.Max.Y; y++ {
+		for x := region.Min.X; x < region.Max.X; x++ {
+			layers.store(r, y*width+x, accumulated[y*width+x])
+			heat.store(y*width+x, accumulated[y*width+x])
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package renderer
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRenderer_Progressive(t *testing.T) {
+	world := testScene()
+
+	// A reference with many samples, to measure the noise of the passes.
+	opts := testOptions()
+	opts.SamplesPerPixel = 256
+	reference, _, _ := New(opts).renderPasses(world)
+
+	opts.SamplesPerPixel, opts.Progressive = 15, true
+	rend := New(opts)
+	pixels, _, _ := rend.renderPasses(world)
+
+	// The passes of 1, 2, 4 and 8 samples add up to the 15 samples per pixel. Every pass is accumulated
+	// into the average of all samples so far, which gets closer to the reference.
+	width, height := int(opts.ImageWidth), int(opts.ImageHeight)
+	accumulated := make([]*pixelSamples, width*height)
+	previousError := math.Inf(1)
+	for _, pass := range [][2]int{{0, 1}, {1, 2}, {3, 4}, {7, 8}} {
+		var squaredError float64
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				idx := y*width + x
+				samples := rend.samplePixel(float64(x), rend.flipY(y), world, pass[0], pass[1])
+				if accumulated[idx] != nil {
+					samples = accumulated[idx].add(samples)
+				}
+				accumulated[idx] = samples
+
+				diff := rend.resolvePixel(samples.sum, samples.weight).Luminance() - reference[idx].Luminance()
+				squaredError += diff * diff
+			}
+		}
+
+		rmsError := math.Sqrt(squaredError / float64(width*height))
+		if rmsError >= previousError {
+			t.Errorf("after %d samples: expected the noise to decrease, got %v after %v",
+				pass[0]+pass[1], rmsError, previousError)
+		}
+		previousError = rmsError
+	}
+
+	// The final image is the average of all passes.
+	for idx, samples := range accumulated {
+		if want := rend.resolvePixel(samples.sum, samples.weight); !pixels[idx].ApproxEqual(want, 1e-12) {
+			t.Fatalf("pixel %d: expected the average %v of all passes, got %v", idx, want, pixels[idx])
+		}
+	}
+}
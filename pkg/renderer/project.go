@@ -0,0 +1,27 @@
+package renderer
+
+import (
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// Project returns the pixel coordinates of the given point in the rendered image.
+// It can be used to draw markers or labels over the objects of a render.
+//
+// The x coordinate grows to the right and the y coordinate grows downward, so that the point lies in the
+// pixel (floor(x), floor(y)), just like the image.Image type. The visible flag is true only if the point
+// is in front of the camera and inside the image.
+func (r *Renderer) Project(point *utils.Vec3) (x, y float64, visible bool) {
+	// The points behind the camera have no projection.
+	if r.opts.Camera.Depth(point) <= 0 {
+		return 0, 0, false
+	}
+
+	viewportX, viewportY, _ := r.opts.Camera.Project(point)
+
+	// Invert the mapping of the pixels to the viewport, which is done by the samplePixel method.
+	// The rows are flipped, since the viewport's y grows upward.
+	x = viewportX * (r.opts.ImageWidth - 1)
+	y = r.opts.ImageHeight - viewportY*(r.opts.ImageHeight-1)
+
+	return x, y, x >= 0 && x < r.opts.ImageWidth && y >= 0 && y < r.opts.ImageHeight
+}
@@ -0,0 +1,31 @@
+package renderer
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRenderer_Project(t *testing.T) {
+	rend := New(testOptions())
+	width, height := int(rend.opts.ImageWidth), int(rend.opts.ImageHeight)
+
+	// A point seen through the center of every pixel projects to that center. The ray is cast with the same
+	// mapping as the renderPixel method, through the pixel's flipped y coordinate.
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			viewportX := (float64(x) + 0.5) / (rend.opts.ImageWidth - 1)
+			viewportY := (rend.flipY(y) + 0.5) / (rend.opts.ImageHeight - 1)
+			point := rend.opts.Camera.CastCenterRay(viewportX, viewportY).At(5)
+
+			projectedX, projectedY, visible := rend.Project(point)
+			// The pixels at the far edges see a little beyond the viewport, but are still in the image.
+			if !visible {
+				t.Fatalf("expected the point seen through pixel (%d, %d) to be visible", x, y)
+			}
+			if math.Abs(projectedX-(float64(x)+0.5)) > 1e-6 || math.Abs(projectedY-(float64(y)+0.5)) > 1e-6 {
+				t.Fatalf("expected the point seen through pixel (%d, %d) to project to its center, got (%v, %v)",
+					x, y, projectedX, projectedY)
+			}
+		}
+	}
+}
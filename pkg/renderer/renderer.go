@@ -1,20 +1,48 @@
 package renderer
 
 import (
+	"errors"
 	"fmt"
 	"image"
+	"io"
 	"math"
+	"sync/atomic"
+	"time"
 
 	"github.com/alitto/pond"
 
 	"github.com/shivanshkc/lightshow/pkg/camera"
+	"github.com/shivanshkc/lightshow/pkg/mats"
 	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/shapes"
 	"github.com/shivanshkc/lightshow/pkg/utils"
 )
 
+const (
+	// defaultShadowEpsilon is the default value of Options.ShadowEpsilon.
+	defaultShadowEpsilon = 0.001
+	// minBVHShapes is the number of shapes from which RenderShapes builds a BVH instead of a Group.
+	minBVHShapes = 8
+	// defaultQueueFactor is the default value of Options.QueueSize, as a multiple of the Options.MaxWorkers.
+	// A few pixels per worker are enough to keep all of them busy.
+	defaultQueueFactor = 4
+	// defaultJPEGQuality is the default value of Options.JPEGQuality.
+	defaultJPEGQuality = 90
+)
+
+// ErrRayLimitExceeded is returned by Render and RenderTile if the render was cut short by the MaxTotalRays option.
+var ErrRayLimitExceeded = errors.New("ray limit exceeded")
+
 // Renderer uses raytracing to render images.
 type Renderer struct {
 	opts *Options
+
+	// rays is the number of rays traced in the current render.
+	rays *atomic.Int64
+	// samples is the number of pixel samples traced in the current render.
+	samples *atomic.Int64
+	// stats are the statistics of the last render.
+	stats *Stats
 }
 
 // Options to create a new renderer.
@@ -25,104 +53,579 @@ type Options struct {
 	ImageWidth  float64
 	ImageHeight float64
 
+	// Mode determines what the renderer shows. It defaults to ModeBeauty.
+	Mode Mode
+
+	// Region is the part of the image that should be rendered, with top-left as the origin.
+	// Pixels outside it are left transparent. An empty region renders the whole image.
+	Region image.Rectangle
+
+	// Background determines the colour of the rays that do not hit anything.
+	// Use SkyFunc to provide it as a function.
+	// It defaults to a white-to-SkyColour vertical gradient.
+	Background Background
 	// SkyColour is the colour of the sky (or background).
+	// It is only used if Background is not provided.
 	SkyColour *utils.Colour
 
+	// GlobalMedium, if provided, fills the whole scene with haze or fog.
+	GlobalMedium *Medium
+
+	// ClipPlane, if provided, removes a part of the scene for cutaway renders.
+	ClipPlane *ClipPlane
+
+	// Sun, if provided, lights the scene with parallel rays from an infinitely far source.
+	Sun *SunLight
+	// Lights are the light sources that can be sampled directly, with UseMIS.
+	// They must also be a part of the world to be visible.
+	Lights []shapes.Light
+
 	// MaxDiffusionDepth is the maximum number of times that a ray is allowed to
 	// diffuse (reflect or refract) before it is considered "dead".
 	//
 	// In simpler words, it produces the "infinity mirror".
 	MaxDiffusionDepth int
+	// DielectricSplits is the number of times that a ray can be split at glass-like surfaces
+	// (that implement mats.Splitter), tracing both the reflected and the refracted rays instead of choosing
+	// one at random. It spends more effort where caustics and reflections form, which reduces their noise.
+	// Every split doubles the rays of the path, so small values like 2 or 3 are enough.
+	// It is ignored by the iterative tracer, which cannot branch.
+	DielectricSplits int
+	// MaxTotalRays is a safety limit on the number of rays cast in a render. Once it is reached, the
+	// remaining samples are skipped, the partial image is written, and Render returns ErrRayLimitExceeded.
+	// Zero or negative values disable it.
+	MaxTotalRays int64
+	// MaxDiffuseBounces and MaxSpecularBounces cap the diffuse and specular bounces of a ray separately,
+	// within the MaxDiffusionDepth. For example, glass and metal can bounce deeply while diffuse bounces
+	// end early to save time. Every bounce is classified by the lobe that scattered it, as reported by
+	// mats.ScatterWithLobe. So, the materials that mix lobes, like Phong, count their diffuse and specular
+	// bounces separately. Zero or negative values leave them capped by MaxDiffusionDepth.
+	MaxDiffuseBounces, MaxSpecularBounces int
+	// Iterative makes the renderer trace rays in a loop instead of recursively.
+	// It ignores DielectricSplits, since a loop cannot branch, and UseMIS. Otherwise, both produce the same
+	// image, apart from tiny floating-point differences, since they add up the light in a different order.
+	Iterative bool
+	// BounceLayers is a debug option that writes the direct and indirect illumination as separate
+	// images next to the output file, with the "-direct" and "-indirect" suffixes.
+	//
+	// The direct layer is the light that reaches the camera after at most one bounce, and the
+	// indirect layer is the rest. Before gamma correction, they add up to the full render.
+	// The rays are always traced iteratively if it is enabled.
+	BounceLayers bool
+
 	// SamplesPerPixel for anti-aliasing.
 	SamplesPerPixel int
+	// SamplePattern determines how the samples are placed within a pixel.
+	// It defaults to SamplePatternRandom.
+	SamplePattern SamplePattern
+	// DisableJitter casts all rays through the pixel centers and the lens center, instead of random points.
+	// With one sample per pixel, every pixel is a single deterministic ray, which helps in debugging
+	// shapes and materials. It disables anti-aliasing and the depth of field effect.
+	DisableJitter bool
+	// PixelFilter weighs the samples of a pixel by their distance from its center.
+	// It defaults to PixelFilterBox, which weighs all samples equally.
+	PixelFilter PixelFilter
+	// PixelFilterRadius is the distance from the pixel center, in pixels, beyond which the samples
+	// get (almost) no weight. It does not affect the box filter. It defaults to 0.5, which is the pixel edge.
+	PixelFilterRadius float64
+	// UseMIS makes the diffuse bounces on the mats.Matte surfaces sample both a direction toward one of the
+	// Lights and a scattered direction, and combines them with multiple importance sampling (MIS). It greatly
+	// reduces the noise of the surfaces lit by area lights. It has no effect without Lights.
+	// To know more, visit-
+	// https://www.pbr-book.org/3ed-2018/Monte_Carlo_Integration/Importance_Sampling#MultipleImportanceSampling
+	UseMIS bool
+	// FireflyClamp is the maximum luminance of a single sample. Brighter samples are scaled down to it,
+	// which removes the stray bright dots (fireflies) caused by rare high-energy paths, at the cost of
+	// slightly darkening very bright highlights. Zero or negative values disable it.
+	FireflyClamp float64
+	// FocusTolerance is the maximum difference between the depth of a surface and the focus distance
+	// for it to be highlighted in ModeFocusPeaking. It defaults to 2% of the focus distance.
+	FocusTolerance float64
+	// OutputColourSpace is the colour space of the output image in ModeBeauty.
+	// It defaults to ColourSpaceGamma2.
+	OutputColourSpace ColourSpace
+	// Grayscale converts every pixel to its luminance. The image is still encoded as RGB, with equal channels.
+	Grayscale bool
+	// PreserveHue makes the over-bright pixels desaturate toward white instead of having every colour
+	// channel clamped independently, which shifts their hue. It only applies to ModeBeauty.
+	PreserveHue bool
+	// ImportanceSampling makes the renderer distribute the samples unevenly, giving more samples
+	// to the pixels with high contrast (like the ones near bright lights) and fewer to flat ones.
+	// The total number of samples remains roughly the same.
+	ImportanceSampling bool
+	// EdgeAdaptive concentrates the anti-aliasing on the edges. A prepass casts a ray through the center
+	// of every pixel to find the silhouettes, creases and material boundaries. Only the pixels on them get
+	// the SamplesPerPixel, while all others get the FlatSamplesPerPixel.
+	// It is ignored with ImportanceSampling.
+	EdgeAdaptive bool
+	// FlatSamplesPerPixel is the number of samples of the pixels that are not on edges, with EdgeAdaptive.
+	// It defaults to 1.
+	FlatSamplesPerPixel int
+	// SupersampleFactor renders the image at this many times the resolution (in both dimensions)
+	// and box-downsamples it. It smooths the edges without increasing the samples per pixel.
+	// Values below 2 disable it.
+	SupersampleFactor int
+	// Progressive renders the samples in passes of 1, 2, 4, 8... per pixel, and writes the image to the
+	// OutputFile after every pass. So, a usable image is available almost immediately and keeps improving.
+	// It is ignored with ImportanceSampling and SupersampleFactor.
+	Progressive bool
+	// PreviewScale renders a quick preview at this fraction of the resolution (in both dimensions),
+	// with proportionally fewer samples per pixel. For example, 0.25 renders at a quarter of the width
+	// and height. The output image has the preview's dimensions. Values outside (0, 1) disable it,
+	// so setting it to zero switches back to full quality.
+	PreviewScale float64
 	// MaxWorkers is the max number of goroutines to be spawned for rendering.
 	MaxWorkers int
+	// PoolStrategy creates the resizing strategy of the worker pool, like pond.Eager or pond.Balanced.
+	// A new strategy is created for every pool. It defaults to pond.Lazy.
+	PoolStrategy func() pond.ResizingStrategy
+	// QueueSize is the max number of pixels waiting for a worker. Scheduling more pixels blocks until
+	// some are done, which caps the memory of the queue for large images.
+	// It defaults to 4 times the MaxWorkers.
+	QueueSize int
+	// Seed for the random numbers used while rendering. Every pixel gets its own generator derived
+	// from it, so the same seed produces the same image regardless of the number of workers.
+	Seed uint64
+	// Progress is where the progress of the render is reported, as a bar with the remaining time.
+	// Progress is not reported if it is nil.
+	Progress io.Writer
+
+	// ShadowEpsilon is the minimum distance at which a ray hit is registered.
+	// It prevents scattered rays from hitting the surface they originate from (shadow acne).
+	// Shapes can override it for the rays leaving their surface, using their Bias.
+	//
+	// Large scenes may need a bigger value, while tiny scenes may need a smaller one.
+	// It defaults to 0.001.
+	ShadowEpsilon float64
 
 	// OutputFile is the path to the output file.
 	OutputFile string
+	// EmbedMetadata embeds the render settings (like the samples per pixel, dimensions and seed)
+	// and the render time into the output file, so that it documents how it was made.
+	// It is supported for PNG (as tEXt chunks) and JPG (as a comment) files only.
+	EmbedMetadata bool
+	// IDPass enables the object-ID pass, which colours every pixel based on the ID of the
+	// shape that it shows. It is written as a PNG next to the output file, with the "-id" suffix.
+	IDPass bool
+	// BitDepth is the number of bits per colour channel of the output image. It can be 8 or 16.
+	// A 16-bit depth reduces banding in smooth gradients but only PNG and TIFF preserve it.
+	// It defaults to 8.
+	BitDepth int
+	// JPEGQuality is the quality of JPG output files, from 1 to 100. Higher values give
+	// better quality but bigger files. It defaults to 90.
+	JPEGQuality int
 }
 
 // New returns a new Renderer for the given options.
 func New(opts *Options) *Renderer {
-	return &Renderer{opts: opts}
+	// Copy the options so that defaults can be applied without mutating the caller's copy.
+	optsCopy := *opts
+	if optsCopy.ShadowEpsilon <= 0 {
+		optsCopy.ShadowEpsilon = defaultShadowEpsilon
+	}
+	if optsCopy.PixelFilterRadius <= 0 {
+		optsCopy.PixelFilterRadius = 0.5
+	}
+	if optsCopy.PoolStrategy == nil {
+		optsCopy.PoolStrategy = pond.Lazy
+	}
+	if optsCopy.QueueSize <= 0 {
+		optsCopy.QueueSize = defaultQueueFactor * optsCopy.MaxWorkers
+	}
+	if optsCopy.JPEGQuality == 0 {
+		optsCopy.JPEGQuality = defaultJPEGQuality
+	}
+	if optsCopy.BitDepth == 0 {
+		optsCopy.BitDepth = 8
+	}
+	if optsCopy.Background == nil {
+		optsCopy.Background = NewGradientBackground(optsCopy.SkyColour, utils.NewColour(1, 1, 1))
+	}
+
+	return &Renderer{opts: &optsCopy, rays: &atomic.Int64{}, samples: &atomic.Int64{}}
 }
 
 func (r *Renderer) Render(world shape) error {
+	return r.render(world, 0)
+}
+
+// render renders the given world. The given setup duration is the time spent in preparing the world,
+// which is reported in the stats.
+func (r *Renderer) render(world shape, setup time.Duration) error {
+	// Validate the bit depth before spending time on rendering.
+	if r.opts.BitDepth != 8 && r.opts.BitDepth != 16 {
+		return fmt.Errorf("unsupported bit depth: %d", r.opts.BitDepth)
+	}
+	if r.opts.JPEGQuality < 1 || r.opts.JPEGQuality > 100 {
+		return fmt.Errorf("invalid JPEG quality: %d", r.opts.JPEGQuality)
+	}
+
+	// Render the preview instead, if enabled.
+	if r.opts.PreviewScale > 0 && r.opts.PreviewScale < 1 {
+		preview := r.previewed()
+		err := preview.render(world, setup)
+		r.stats = preview.stats
+		return err
+	}
+
+	start := time.Now()
+	r.rays.Store(0)
+	r.samples.Store(0)
+
+	// Dimensions of the final image.
+	width, height := int(r.opts.ImageWidth), int(r.opts.ImageHeight)
+
+	var pixels, idPixels []*utils.Colour
+	var layers *bounceLayers
+	tracingStart := time.Now()
+	if r.opts.SupersampleFactor > 1 {
+		// Render at a higher resolution and downsample the results.
+		pixels, idPixels, layers = r.supersampled().renderPasses(world)
+		pixels, idPixels, layers = r.downsample(pixels), r.downsampleIDs(idPixels), r.downsampleLayers(layers)
+	} else {
+		pixels, idPixels, layers = r.renderPasses(world)
+	}
+	tracing := time.Since(tracingStart)
+
+	// Encode the image.
+	encodingStart := time.Now()
+	encodeOpts := &encodeOptions{jpegQuality: r.opts.JPEGQuality}
+	if r.opts.EmbedMetadata {
+		encodeOpts.metadata = r.metadata(time.Since(start))
+	}
+
+	img := buildImage(pixels, width, height, r.opts.BitDepth)
+	if err := encodeImage(img, r.opts.OutputFile, encodeOpts); err != nil {
+		return fmt.Errorf("failed to encode image: %w", err)
+	}
+
+	// Encode the object-ID pass.
+	if idPixels != nil {
+		idImg := buildImage(idPixels, width, height, 8)
+		if err := encodeImage(idImg, r.auxiliaryFile("id"), nil); err != nil {
+			return fmt.Errorf("failed to encode object-ID pass: %w", err)
+		}
+	}
+
+	// Encode the bounce layers.
+	if layers != nil {
+		if err := layers.encode(r, width, height); err != nil {
+			return fmt.Errorf("failed to encode bounce layers: %w", err)
+		}
+	}
+
+	// A single end time keeps the phases within the Duration.
+	end := time.Now()
+	r.stats = newStats(world, r.rays.Load(), r.samples.Load(), end.Sub(start)+setup)
+	r.stats.Setup, r.stats.Tracing, r.stats.Encoding = setup, tracing, end.Sub(encodingStart)
+	if r.opts.Progress != nil {
+		_, _ = fmt.Fprintln(r.opts.Progress, r.stats)
+	}
+
+	if r.rayLimitReached() {
+		return fmt.Errorf("%w: %d rays cast with a limit of %d, the output is partial",
+			ErrRayLimitExceeded, r.rays.Load(), r.opts.MaxTotalRays)
+	}
+
+	return nil
+}
+
+// RenderShapes renders the given list of shapes, as if they were a single group.
+// It saves building an acceleration structure for the common case of a flat list of shapes.
+//
+// Lists of at least minBVHShapes shapes are put in a BVH. Smaller lists are put in a plain Group,
+// for which testing every shape is cheaper than traversing a hierarchy.
+// The time spent in building the structure is reported as the setup time in the stats.
+func (r *Renderer) RenderShapes(list []shapes.Shape) error {
+	start := time.Now()
+
+	var world shape = shapes.NewGroup(list...)
+	if len(list) >= minBVHShapes {
+		world = shapes.NewBVH(list...)
+	}
+
+	return r.render(world, time.Since(start))
+}
+
+// rayLimitReached tells whether the rays cast so far have reached the MaxTotalRays.
+func (r *Renderer) rayLimitReached() bool {
+	return r.opts.MaxTotalRays > 0 && r.rays.Load() >= r.opts.MaxTotalRays
+}
+
+// renderPasses renders the image, along with the enabled passes, at the configured resolution.
+// The passes that are not enabled are nil.
+func (r *Renderer) renderPasses(world shape) (pixels, idPixels []*utils.Colour, layers *bounceLayers) {
+	// Final colours of all pixels, in row-major order with top-left as the origin.
+	width, height := int(r.opts.ImageWidth), int(r.opts.ImageHeight)
+	pixels = make([]*utils.Colour, width*height)
+	// Colours of the object-ID pass, if enabled.
+	if r.opts.IDPass {
+		idPixels = make([]*utils.Colour, width*height)
+	}
+	// Colours of the bounce layers, if enabled.
+	if r.opts.BounceLayers {
+		layers = newBounceLayers(width * height)
+	}
+	// Costs of the pixels, in the heat map mode.
+	var heat *heatMap
+	if r.opts.Mode == ModeHeatMap {
+		heat = newHeatMap(width * height)
+	}
+
+	// Only the pixels inside the region are rendered.
+	region := r.region()
+
+	if r.opts.ClipPlane != nil {
+		world = newClippedShape(world, r.opts.ClipPlane)
+	}
+
+	switch {
+	case r.opts.ImportanceSampling:
+		r.renderImportanceSampled(world, region, pixels, layers, heat)
+	case r.opts.EdgeAdaptive:
+		r.renderEdgeAdaptive(world, region, pixels, layers, heat)
+	case r.opts.Progressive:
+		r.renderProgressive(world, region, pixels, layers, heat)
+	default:
+		r.forEachPixel(region, func(x, y int) {
+			samples := r.samplePixel(float64(x), r.flipY(y), world, 0, r.opts.SamplesPerPixel)
+			pixels[y*width+x] = r.resolvePixel(samples.sum, samples.weight)
+			layers.store(r, y*width+x, samples)
+			heat.store(y*width+x, samples)
+		})
+	}
+
+	// Colour the pixels by their costs, now that the costliest one is known.
+	heat.apply(pixels)
+
+	// Highlight the pixels that are in focus.
+	if r.opts.Mode == ModeFocusPeaking {
+		r.forEachPixel(region, func(x, y int) {
+			pixels[y*width+x] = r.peakFocus(float64(x), r.flipY(y), world, pixels[y*width+x])
+		})
+	}
+
+	// Render the object-ID pass.
+	if idPixels != nil {
+		r.forEachPixel(region, func(x, y int) {
+			idPixels[y*width+x] = r.renderIDPixel(float64(x), r.flipY(y), world)
+		})
+	}
+
+	return pixels, idPixels, layers
+}
+
+// region returns the part of the image that should be rendered.
+// It is the configured region clipped to the image bounds, or the whole image if no region is configured.
+func (r *Renderer) region() image.Rectangle {
+	bounds := image.Rect(0, 0, int(r.opts.ImageWidth), int(r.opts.ImageHeight))
+	if r.opts.Region.Empty() {
+		return bounds
+	}
+
+	return r.opts.Region.Intersect(bounds)
+}
+
+// forEachPixel calls the given function concurrently for every pixel in the region
+// and waits for all the calls to return.
+//
+// The x and y arguments of the function are image coordinates, with top-left as the origin.
+func (r *Renderer) forEachPixel(region image.Rectangle, fn func(x, y int)) {
 	// Create a pool for concurrent processing.
-	pixelCount := r.opts.ImageHeight * r.opts.ImageWidth
-	workerPool := pond.New(r.opts.MaxWorkers, int(pixelCount), pond.Strategy(pond.Lazy()))
-
-	// Create a new image.
-	img := image.NewRGBA(image.Rectangle{
-		image.Point{0, 0},
-		image.Point{int(r.opts.ImageWidth), int(r.opts.ImageHeight)},
-	})
-
-	// Two nested loops for traversing every pixel on the screen.
-	for j := 0.0; j < r.opts.ImageHeight; j++ {
-		for i := 0.0; i < r.opts.ImageWidth; i++ {
+	// Its queue is bounded, so the loop below blocks whenever it is full.
+	pixelCount := region.Dx() * region.Dy()
+	workerPool := r.newWorkerPool()
+
+	// Report progress while rendering.
+	var completed atomic.Int64
+	stopProgress := r.startProgress(&completed, pixelCount)
+
+	// Two nested loops for traversing every pixel in the region.
+	for y := region.Min.Y; y < region.Max.Y; y++ {
+		for x := region.Min.X; x < region.Max.X; x++ {
 			// Copy loop variables for safety in goroutines.
-			ii, jj, jImg := i, j, r.opts.ImageHeight-j-1
+			xx, yy := x, y
 			// Schedule the task.
 			workerPool.Submit(func() {
-				// Here, we have to use "jImg" instead of "j" because
-				// Go's image package treats top-left as the origin,
-				// instead of bottom-left.
-				colour := r.renderPixelWithAA(ii, jImg, world)
-				img.Set(int(ii), int(jj), colour.ToStd())
-
-				// TODO: Log progress without performance impact.
+				fn(xx, yy)
+				completed.Add(1)
 			})
 		}
 	}
 
-	// Await render completion.
+	// Await completion.
 	workerPool.StopAndWait()
+	stopProgress()
+}
 
-	// Encode the image.
-	if err := encodeImage(img, r.opts.OutputFile); err != nil {
-		return fmt.Errorf("failed to encode image: %w", err)
-	}
+// newWorkerPool returns a new pool of MaxWorkers workers, with the PoolStrategy and a queue of QueueSize tasks.
+func (r *Renderer) newWorkerPool() *pond.WorkerPool {
+	return pond.New(r.opts.MaxWorkers, r.opts.QueueSize, pond.Strategy(r.opts.PoolStrategy()))
+}
 
-	return nil
+// flipY converts the given image y coordinate to the y coordinate used for casting rays.
+//
+// We have to flip the "y" coordinate because Go's image package treats top-left
+// as the origin, instead of bottom-left.
+func (r *Renderer) flipY(y int) float64 {
+	return r.opts.ImageHeight - float64(y) - 1
+}
+
+// pixelContext holds the state of rendering the samples of a single pixel.
+// It is not shared between goroutines, so it needs no synchronization.
+type pixelContext struct {
+	// rng is the source of all randomness for the pixel.
+	rng *random.Generator
+	// rays is the number of rays traced for the pixel so far.
+	rays int
 }
 
-// renderPixelWithAA is called for every pixel on the screen.
-// Its job is to determine the colour of the given pixel with anti-aliasing.
-func (r *Renderer) renderPixelWithAA(x, y float64, world shape) *utils.Colour {
-	colour := utils.NewColour(0, 0, 0)
+// pixelSamples is the accumulated result of rendering some samples of a pixel.
+type pixelSamples struct {
+	// sum is the sum of the colours of the samples.
+	sum *utils.Colour
+	// directSum is the sum of the direct illumination of the samples.
+	// It is only meaningful if the bounce layers are enabled.
+	directSum *utils.Colour
+	// weight is the sum of the pixel filter weights of the samples.
+	// Both sums are weighted, so they are divided by it to get the averages.
+	weight float64
 
-	// Process the configured number of samples for every pixel.
-	for s := 0; s < r.opts.SamplesPerPixel; s++ {
-		u := x + random.Float()
-		v := y + random.Float()
+	// count is the number of samples traced. It excludes the samples skipped once the ray limit is reached,
+	// but includes the discarded non-finite ones, whose rays are still counted.
+	count int
+	// rays is the number of rays traced for the samples.
+	rays int
+}
 
-		pixelCol := r.renderPixel(u, v, world)
-		colour = colour.Add(pixelCol)
+// add returns the combined result of these and the given samples.
+func (p *pixelSamples) add(other *pixelSamples) *pixelSamples {
+	return &pixelSamples{
+		sum:       p.sum.Add(other.sum),
+		directSum: p.directSum.Add(other.directSum),
+		weight:    p.weight + other.weight,
+		count:     p.count + other.count,
+		rays:      p.rays + other.rays,
 	}
+}
+
+// samplePixel renders the given number of samples of the pixel at x and y.
+//
+// The "first" argument is the index of the first sample, which matters for the sample pattern.
+func (r *Renderer) samplePixel(x, y float64, world shape, first, count int) *pixelSamples {
+	samples := &pixelSamples{sum: utils.NewColour(0, 0, 0), directSum: utils.NewColour(0, 0, 0)}
+	ctx := &pixelContext{rng: r.pixelGenerator(x, y, first)}
+
+	for s := first; s < first+count; s++ {
+		// The remaining samples are skipped once the ray limit is reached.
+		if r.rayLimitReached() {
+			break
+		}
+
+		offsetX, offsetY := r.sampleOffset(x, y, s, ctx.rng)
+		u, v := x+offsetX, y+offsetY
+
+		pixelCol, directCol := r.renderPixel(u, v, world, ctx)
+		samples.count++
+
+		// Invalid samples would poison the whole pixel, so they are discarded.
+		if !isFinite(pixelCol) {
+			continue
+		}
 
-	// Take the average of the colour and do gamma correction.
-	spp := float64(r.opts.SamplesPerPixel)
-	return utils.NewColour(
-		math.Sqrt(colour.R/spp),
-		math.Sqrt(colour.G/spp),
-		math.Sqrt(colour.B/spp),
-	)
+		// Scale down the fireflies to the clamp, keeping their hue.
+		if luminance := pixelCol.Luminance(); r.opts.FireflyClamp > 0 && luminance > r.opts.FireflyClamp {
+			factor := r.opts.FireflyClamp / luminance
+			pixelCol, directCol = pixelCol.Scale(factor), directCol.Scale(factor)
+		}
+
+		// Weigh the sample by its position relative to the pixel center.
+		// The sums belong to this function, so they are updated in place to avoid allocations.
+		weight := r.filterWeight(offsetX-0.5, offsetY-0.5)
+		samples.sum.R, samples.sum.G, samples.sum.B = samples.sum.R+pixelCol.R*weight,
+			samples.sum.G+pixelCol.G*weight, samples.sum.B+pixelCol.B*weight
+		samples.directSum.R, samples.directSum.G, samples.directSum.B = samples.directSum.R+directCol.R*weight,
+			samples.directSum.G+directCol.G*weight, samples.directSum.B+directCol.B*weight
+		samples.weight += weight
+	}
+
+	samples.rays = ctx.rays
+	r.rays.Add(int64(ctx.rays))
+	r.samples.Add(int64(samples.count))
+
+	return samples
+}
+
+// isFinite tells whether all components of the given colour are finite, that is, neither NaN nor infinite.
+func isFinite(colour *utils.Colour) bool {
+	for _, component := range [3]float64{colour.R, colour.G, colour.B} {
+		if math.IsNaN(component) || math.IsInf(component, 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// resolvePixel converts the weighted sum of samples, with the given total weight, to the final colour of the pixel.
+func (r *Renderer) resolvePixel(sum *utils.Colour, weight float64) *utils.Colour {
+	// Take the average of the colour. A pixel without any valid samples is black.
+	if weight <= 0 {
+		return utils.NewColour(0, 0, 0)
+	}
+	average := sum.DivScalar(weight)
+	if r.opts.Grayscale {
+		luminance := average.Luminance()
+		average = utils.NewColour(luminance, luminance, luminance)
+	}
+	if !r.opts.Mode.isGammaCorrected() {
+		return average
+	}
+
+	// Do gamma correction.
+	corrected := r.opts.OutputColourSpace.encode(average)
+	if r.opts.PreserveHue {
+		return corrected.ClampPreservingHue()
+	}
+	return corrected
 }
 
 // renderPixel is called for every pixel on the screen.
 // Its job is to determine the colour of the given pixel (without anti-aliasing).
-func (r *Renderer) renderPixel(x, y float64, world shape) *utils.Colour {
+//
+// It also returns the direct illumination part of the colour, if the bounce layers are enabled.
+// Otherwise, the direct part is the same as the colour.
+func (r *Renderer) renderPixel(x, y float64, world shape, ctx *pixelContext) (colour, direct *utils.Colour) {
 	// Bring x and y in the [0, 1) interval.
 	x /= (r.opts.ImageWidth - 1)
 	y /= (r.opts.ImageHeight - 1)
 
 	// Create a ray and trace it to determine the final pixel colour.
-	return r.traceRay(r.opts.Camera.CastRay(x, y), world, r.opts.MaxDiffusionDepth)
+	ray := r.opts.Camera.CastRay(x, y, ctx.rng)
+	if r.opts.DisableJitter {
+		ray = r.opts.Camera.CastCenterRay(x, y)
+	}
+	if r.opts.Mode == ModeNormals {
+		ctx.rays++
+		colour = r.shadeNormal(ray, world)
+		return colour, colour
+	}
+	if r.opts.Iterative || r.opts.BounceLayers {
+		return r.traceRayIterative(ray, world, r.opts.MaxDiffusionDepth, ctx)
+	}
+
+	colour = r.traceRay(ray, world, r.hitInterval(), r.opts.MaxDiffusionDepth, r.newBounceBudget(), 0, ctx)
+	return colour, colour
 }
 
 // traceRay traces the provided ray upto the given diffusion depth and returns its final colour.
-func (r *Renderer) traceRay(ray *utils.Ray, world shape, diffusionDepth int) *utils.Colour {
+// Only the hits within the given interval of distances are registered.
+// The ray also ends when the given budget runs out of bounces of the kind that it is about to make.
+//
+// The scatterPDF is the probability density with which the ray was scattered by a surface that also
+// sampled the lights, for weighting the light that it finds with MIS. It is zero for all other rays.
+func (r *Renderer) traceRay(ray *utils.Ray, world shape, interval utils.Interval, diffusionDepth int,
+	budget bounceBudget, scatterPDF float64, ctx *pixelContext,
+) *utils.Colour {
 	// If diffusion depth is reached, the ray is considered dead.
 	// So, the colour is black.
 	if diffusionDepth < 1 {
@@ -130,28 +633,151 @@ func (r *Renderer) traceRay(ray *utils.Ray, world shape, diffusionDepth int) *ut
 	}
 
 	// Hit the world. B-)
-	if hitInfo, isHit := world.Hit(ray, 0.001, math.MaxFloat64); isHit {
+	ctx.rays++
+	if hitInfo, isHit := world.Hit(ray, interval); isHit {
+		// Light emitted by the material, if any, and the sunlight on it.
+		emitted := r.surfaceLight(ray, hitInfo, world, scatterPDF, ctx)
+
+		// Trace both the reflected and the refracted rays, if possible.
+		if split, isSplit := r.traceSplit(ray, hitInfo, world, diffusionDepth, budget, ctx); isSplit {
+			return r.opts.GlobalMedium.apply(emitted.Add(split), hitInfo.Distance)
+		}
+
 		// Scatter the ray using the material of the shape.
-		scat, atten, isScat := hitInfo.Mat.Scatter(ray, hitInfo)
-		// Return black if the ray got absorbed.
-		if !isScat {
-			return utils.NewColour(0, 0, 0)
+		scat, atten, lobe, isScat := mats.ScatterWithLobe(hitInfo.Mat, ray, hitInfo, ctx.rng)
+		// Only the emitted light remains if the ray got absorbed or ran out of bounces.
+		if !isScat || !budget.spend(lobe) {
+			return r.opts.GlobalMedium.apply(emitted, hitInfo.Distance)
+		}
+
+		continuePath(scat, ray)
+
+		// Sample a light directly, and let the scattered ray know its density for weighting the light it finds.
+		var nextScatterPDF float64
+		if matte, ok := r.misMatte(hitInfo); ok {
+			emitted = emitted.Add(r.sampleLight(ray, hitInfo, matte, world, ctx))
+			nextScatterPDF = matte.PDF(hitInfo, scat.Dir)
 		}
 
 		// Calculate the colour of the scattered ray.
 		// This is where nested reflections/refractions of the ray are considered.
-		scatRayColour := r.traceRay(scat, world, diffusionDepth-1)
-		// Add the attenuation to the colour.
-		return utils.NewColour(
-			scatRayColour.R*atten.R,
-			scatRayColour.G*atten.G,
-			scatRayColour.B*atten.B,
-		)
+		scatRayColour := r.traceRay(
+			scat, world, r.surfaceInterval(hitInfo), diffusionDepth-1, budget, nextScatterPDF, ctx)
+		// Add the attenuation to the colour, and the effect of the medium on the way to the point-of-hit.
+		return r.opts.GlobalMedium.apply(emitted.Add(scatRayColour.Attenuate(atten)), hitInfo.Distance)
 	}
 
 	// Background.
-	// The {0.5 + (x + 1)} formula converts the [-1, 1] interval to [0, 1]
-	bgColourIntensity := 0.5 * (ray.Dir.Y + 1)
-	// Background colour using a gradient.
-	return utils.NewColour(1, 1, 1).Lerp(r.opts.SkyColour, bgColourIntensity)
+	return r.opts.GlobalMedium.apply(r.opts.Background.Colour(ray.Dir), math.Inf(1))
+}
+
+// traceRayIterative is the iterative equivalent of traceRay.
+//
+// Instead of recursing, it follows the path of the ray in a loop while keeping track of
+// the accumulated colour and the throughput (the product of all attenuations so far).
+//
+// It also returns the direct part of the colour, which is the light that reached the camera
+// after at most one bounce.
+func (r *Renderer) traceRayIterative(
+	ray *utils.Ray, world shape, diffusionDepth int, ctx *pixelContext,
+) (colour, direct *utils.Colour) {
+	colour, direct = utils.NewColour(0, 0, 0), utils.NewColour(0, 0, 0)
+	throughput := utils.NewColour(1, 1, 1)
+	interval := r.hitInterval()
+	budget := r.newBounceBudget()
+
+	// Once the diffusion depth is reached, the ray is considered dead and adds nothing.
+	for bounces := 0; bounces < diffusionDepth; bounces++ {
+		var contribution *utils.Colour
+		// Distance that the ray travels through the medium, if any.
+		distance := math.Inf(1)
+
+		ctx.rays++
+		hitInfo, isHit := world.Hit(ray, interval)
+		if isHit {
+			// Light emitted by the material, if any, and the sunlight on it.
+			contribution = r.surfaceLight(ray, hitInfo, world, 0, ctx)
+			distance = hitInfo.Distance
+		} else {
+			// Background.
+			contribution = r.opts.Background.Colour(ray.Dir)
+		}
+
+		// The medium dims everything beyond it, including the later bounces.
+		contribution = r.opts.GlobalMedium.apply(contribution, distance).Attenuate(throughput)
+		throughput = throughput.Scale(r.opts.GlobalMedium.transmittance(distance))
+
+		colour = colour.Add(contribution)
+		if bounces <= 1 {
+			direct = direct.Add(contribution)
+		}
+
+		if !isHit {
+			return colour, direct
+		}
+
+		// Scatter the ray using the material of the shape.
+		scat, atten, lobe, isScat := mats.ScatterWithLobe(hitInfo.Mat, ray, hitInfo, ctx.rng)
+		// Only the emitted light remains if the ray got absorbed or ran out of bounces.
+		if !isScat || !budget.spend(lobe) {
+			return colour, direct
+		}
+
+		throughput = throughput.Attenuate(atten)
+		continuePath(scat, ray)
+		ray, interval = scat, r.surfaceInterval(hitInfo)
+	}
+
+	return colour, direct
+}
+
+// continuePath carries the state of the inbound ray that lasts for the whole path over to the scattered ray.
+func continuePath(scattered, inbound *utils.Ray) {
+	// The scattered ray exists at the same time as the inbound one.
+	scattered.Time = inbound.Time
+	// It keeps the colour channel of the path, unless the material just picked one.
+	if scattered.Channel == 0 {
+		scattered.Channel = inbound.Channel
+	}
+}
+
+// hitInterval returns the interval of distances along a ray in which hits are registered.
+func (r *Renderer) hitInterval() utils.Interval {
+	return utils.NewInterval(r.opts.ShadowEpsilon, math.MaxFloat64)
+}
+
+// surfaceInterval returns the interval of distances in which hits are registered, for the rays that
+// originate at the given point-of-hit. It uses the bias of the hit shape, if any, instead of the ShadowEpsilon.
+func (r *Renderer) surfaceInterval(hitInfo *mats.RayHit) utils.Interval {
+	if hitInfo.Bias > 0 {
+		return utils.NewInterval(hitInfo.Bias, math.MaxFloat64)
+	}
+	return r.hitInterval()
+}
+
+// surfaceLight returns the light that leaves the given point-of-hit toward the ray's origin, without
+// tracing any further bounces. It is the light emitted by the material and the sunlight reflected by it.
+//
+// The emitted light is weighted with MIS if the ray was scattered with the given non-zero density.
+func (r *Renderer) surfaceLight(
+	ray *utils.Ray, hitInfo *mats.RayHit, world shape, scatterPDF float64, ctx *pixelContext,
+) *utils.Colour {
+	emitted := emission(ray, hitInfo)
+	if scatterPDF > 0 {
+		emitted = emitted.Scale(powerHeuristic(scatterPDF, r.lightPDF(ray)))
+	}
+	// Skip the sunlight without a sun, to save the allocations.
+	if r.opts.Sun == nil {
+		return emitted
+	}
+	return emitted.Add(r.sunlight(ray, hitInfo, world, ctx))
+}
+
+// emission returns the light emitted by the material at the given point-of-hit.
+// It is black if the material does not emit light.
+func emission(ray *utils.Ray, hitInfo *mats.RayHit) *utils.Colour {
+	if emitter, ok := hitInfo.Mat.(mats.Emitter); ok {
+		return emitter.Emit(ray, hitInfo)
+	}
+	return utils.NewColour(0, 0, 0)
 }
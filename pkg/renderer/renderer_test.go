@@ -0,0 +1,114 @@
+package renderer_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/camera"
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/renderer"
+	"github.com/shivanshkc/lightshow/pkg/scenes"
+	"github.com/shivanshkc/lightshow/pkg/shapes"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// BenchmarkRender renders a small, fixed scene with a fixed seed, so that the results are comparable
+// across runs. Apart from the time per render, it reports the rays traced per second.
+func BenchmarkRender(b *testing.B) {
+	scene := scenes.Studio(4.0 / 3)
+	scene.World.Add(
+		shapes.NewSphere(utils.NewVec3(0, 0.5, 0), 0.5, mats.NewMatte(utils.NewColour(0.8, 0.3, 0.2))),
+		shapes.NewSphere(utils.NewVec3(-1.1, 0.4, 0.3), 0.4, mats.NewGlass(1.5)),
+		shapes.NewSphere(utils.NewVec3(1.1, 0.4, 0.3), 0.4, mats.NewMetallic(utils.NewColour(0.8, 0.8, 0.8), 0.05)),
+	)
+
+	opts := &renderer.Options{
+		Camera:            camera.New(scene.Camera),
+		ImageWidth:        64,
+		ImageHeight:       48,
+		Background:        scene.Background,
+		MaxDiffusionDepth: 8,
+		SamplesPerPixel:   8,
+		MaxWorkers:        4,
+		Seed:              1,
+		OutputFile:        filepath.Join(b.TempDir(), "bench.png"),
+	}
+
+	var raysPerSecond float64
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rend := renderer.New(opts)
+		if err := rend.Render(scene.World); err != nil {
+			b.Fatalf("failed to render: %v", err)
+		}
+		raysPerSecond += rend.Stats().RaysPerSecond()
+	}
+
+	b.ReportMetric(raysPerSecond/float64(b.N), "rays/s")
+}
+
+func TestRenderer_RenderShapes(t *testing.T) {
+	world, opts := tileTestScene(t)
+
+	// Enough small spheres for a BVH, along with the spheres of the scene.
+	list := append([]shapes.Shape(nil), world.Shapes...)
+	for i := 0; i < 30; i++ {
+		x, z := float64(i%6)*0.3-0.75, -float64(i/6)*0.3-0.5
+		mat := mats.NewMatte(utils.NewColour(0.8, 0.2, 0.2))
+		list = append(list, shapes.NewSphere(utils.NewVec3(x, -0.4, z), 0.1, mat))
+	}
+
+	tests := []struct {
+		name  string
+		list  []shapes.Shape
+		world shapes.Shape
+	}{
+		{name: "large", list: list, world: shapes.NewBVH(list...)},
+		{name: "small", list: world.Shapes, world: shapes.NewGroup(world.Shapes...)},
+	}
+
+	for _, test := range tests {
+		dir := t.TempDir()
+
+		opts.OutputFile = filepath.Join(dir, "manual.png")
+		if err := renderer.New(opts).Render(test.world); err != nil {
+			t.Fatalf("%s: failed to render: %v", test.name, err)
+		}
+
+		opts.OutputFile = filepath.Join(dir, "shapes.png")
+		rend := renderer.New(opts)
+		if err := rend.RenderShapes(test.list); err != nil {
+			t.Fatalf("%s: failed to render the shapes: %v", test.name, err)
+		}
+		if got := rend.Stats().Primitives; got != len(test.list) {
+			t.Errorf("%s: expected %d primitives, got %d", test.name, len(test.list), got)
+		}
+
+		manual, fromShapes := decodePNG(t, filepath.Join(dir, "manual.png")), decodePNG(t, opts.OutputFile)
+		if !reflect.DeepEqual(manual, fromShapes) {
+			t.Errorf("%s: expected RenderShapes to produce the same image as a manual build", test.name)
+		}
+	}
+}
+
+func TestRenderer_Hash(t *testing.T) {
+	hashScene := func(radius float64) uint64 {
+		world, opts := tileTestScene(t)
+		world.Shapes[1].(*shapes.Sphere).Radius = radius
+
+		hash, err := renderer.New(opts).Hash(world)
+		if err != nil {
+			t.Fatalf("failed to hash the scene: %v", err)
+		}
+		return hash
+	}
+
+	// The scenes are built separately, with different output files, so they share no pointers.
+	if first, second := hashScene(0.5), hashScene(0.5); first != second {
+		t.Errorf("expected identical scenes to hash the same, got %d and %d", first, second)
+	}
+	if first, second := hashScene(0.5), hashScene(0.6); first == second {
+		t.Errorf("expected a different sphere radius to change the hash")
+	}
+}
@@ -0,0 +1,126 @@
+package renderer
+
+import (
+	"math"
+
+	"github.com/shivanshkc/lightshow/pkg/random"
+)
+
+// SamplePattern determines how the anti-aliasing samples of a pixel are placed within it.
+type SamplePattern int
+
+const (
+	// SamplePatternRandom places every sample at a random position within the pixel.
+	SamplePatternRandom SamplePattern = iota
+	// SamplePatternStratified divides the pixel into a square grid of strata and places
+	// one sample at a random position within every stratum.
+	//
+	// If the samples per pixel is not a perfect square, the leftover samples are random.
+	SamplePatternStratified
+	// SamplePatternStratifiedBlueNoise places one sample in every stratum (like SamplePatternStratified),
+	// at a position given by a per-pixel Cranley–Patterson rotation.
+	//
+	// The rotations come from a blue-noise-like pattern, so neighbouring pixels are decorrelated,
+	// which reduces visible structured noise at low sample counts.
+	SamplePatternStratifiedBlueNoise
+)
+
+// sampleOffset returns the position, within the pixel at x and y, of the sample with the given index.
+// Both components of the position lie in the [0, 1) interval.
+func (r *Renderer) sampleOffset(x, y float64, sample int, rng *random.Generator) (float64, float64) {
+	// Without jitter, all samples go through the pixel center.
+	if r.opts.DisableJitter {
+		return 0.5, 0.5
+	}
+
+	// Side length of the strata grid.
+	gridSize := int(math.Sqrt(float64(r.opts.SamplesPerPixel)))
+
+	// Leftover samples (those that do not fit the grid) are always random.
+	if r.opts.SamplePattern == SamplePatternRandom || sample >= gridSize*gridSize {
+		return rng.Float(), rng.Float()
+	}
+
+	// Position within the stratum.
+	var offsetX, offsetY float64
+	switch r.opts.SamplePattern {
+	case SamplePatternStratifiedBlueNoise:
+		offsetX, offsetY = rotation(x, y)
+	default:
+		offsetX, offsetY = rng.Float(), rng.Float()
+	}
+
+	stratumX, stratumY := float64(sample%gridSize), float64(sample/gridSize)
+	return (stratumX + offsetX) / float64(gridSize), (stratumY + offsetY) / float64(gridSize)
+}
+
+// pixelGenerator returns the random number generator for the samples of the pixel at x and y,
+// starting at the given sample index.
+//
+// It is derived from the configured seed, so the result does not depend on which worker renders the pixel.
+// Different sample indices get different generators, so the samples added later by importance sampling
+// do not repeat the earlier ones.
+func (r *Renderer) pixelGenerator(x, y float64, first int) *random.Generator {
+	pixelIndex := uint64(y)*uint64(r.opts.ImageWidth) + uint64(x)
+	return random.New(r.opts.Seed ^ (pixelIndex<<32 | uint64(first)))
+}
+
+// rotation returns the Cranley–Patterson rotation for the pixel at x and y.
+//
+// It uses Interleaved Gradient Noise, which is deterministic, cheap to compute and has
+// blue-noise-like properties. To know more, visit-
+// https://blog.demofox.org/2022/01/01/interleaved-gradient-noise-a-different-kind-of-low-discrepancy-sequence/
+func rotation(x, y float64) (float64, float64) {
+	// The second component samples the noise at a shifted location to decorrelate it from the first.
+	return interleavedGradientNoise(x, y), interleavedGradientNoise(x+5.588238, y+5.588238)
+}
+
+// interleavedGradientNoise returns the noise value in the [0, 1) interval for the given pixel.
+func interleavedGradientNoise(x, y float64) float64 {
+	_, inner := math.Modf(0.06711056*x + 0.00583715*y)
+	_, value := math.Modf(52.9829189 * inner)
+	return value
+}
+
+// PixelFilter determines how the samples of a pixel are weighed when they are averaged.
+type PixelFilter int
+
+const (
+	// PixelFilterBox weighs all samples equally.
+	PixelFilterBox PixelFilter = iota
+	// PixelFilterTent weighs the samples linearly less as they get farther from the pixel center,
+	// along both axes, down to zero at the filter radius.
+	PixelFilterTent
+	// PixelFilterGaussian weighs the samples using a Gaussian curve around the pixel center,
+	// shifted down to reach zero at the filter radius. It is smoother than the tent filter.
+	PixelFilterGaussian
+)
+
+// minFilterWeight is the minimum weight of a sample. It keeps the pixels whose samples all lie
+// beyond the filter radius from being left without any weight.
+const minFilterWeight = 1e-6
+
+// filterWeight returns the weight of a sample at the given offset from the pixel center, in pixels.
+//
+// To know more about the filters, visit-
+// https://pbr-book.org/3ed-2018/Sampling_and_Reconstruction/Image_Reconstruction
+func (r *Renderer) filterWeight(offsetX, offsetY float64) float64 {
+	radius := r.opts.PixelFilterRadius
+
+	var weight float64
+	switch r.opts.PixelFilter {
+	case PixelFilterTent:
+		weight = math.Max(0, 1-math.Abs(offsetX)/radius) * math.Max(0, 1-math.Abs(offsetY)/radius)
+	case PixelFilterGaussian:
+		// The standard deviation is half the radius, so the curve is mostly within the radius.
+		sigma := radius / 2
+		gaussian := func(offset float64) float64 {
+			return math.Max(0, math.Exp(-offset*offset/(2*sigma*sigma))-math.Exp(-radius*radius/(2*sigma*sigma)))
+		}
+		weight = gaussian(offsetX) * gaussian(offsetY)
+	default:
+		return 1
+	}
+
+	return math.Max(weight, minFilterWeight)
+}
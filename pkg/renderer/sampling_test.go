@@ -0,0 +1,183 @@
+package renderer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/camera"
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/shapes"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestRenderer_FilterWeight(t *testing.T) {
+	opts := testOptions()
+	opts.PixelFilter, opts.PixelFilterRadius = PixelFilterGaussian, 1
+	gaussian := New(opts)
+
+	// The weights fall off from the center toward the edges, and along the diagonal even more.
+	center, halfway, edge, corner := gaussian.filterWeight(0, 0), gaussian.filterWeight(0.25, 0),
+		gaussian.filterWeight(0.5, 0), gaussian.filterWeight(0.5, 0.5)
+	if !(center > halfway && halfway > edge && edge > corner) {
+		t.Errorf("expected the Gaussian weights to fall off, got center %v, halfway %v, edge %v and corner %v",
+			center, halfway, edge, corner)
+	}
+	if weight := gaussian.filterWeight(-0.25, 0); weight != halfway {
+		t.Errorf("expected the Gaussian weights to be symmetric, got %v and %v", weight, halfway)
+	}
+
+	// Beyond the radius, only the minimum weight is left.
+	if weight := gaussian.filterWeight(1.5, 0); weight != minFilterWeight {
+		t.Errorf("expected the minimum weight beyond the radius, got %v", weight)
+	}
+}
+
+func TestRenderer_FilterWeight_Box(t *testing.T) {
+	box := New(testOptions())
+
+	for _, offset := range [][2]float64{{0, 0}, {0.25, -0.1}, {0.5, 0.5}, {-0.5, 0.3}} {
+		if weight := box.filterWeight(offset[0], offset[1]); weight != 1 {
+			t.Errorf("expected the box weight of 1 at %v, got %v", offset, weight)
+		}
+	}
+
+	// With equal weights, the weighted average of the samples is their plain average, as without a filter.
+	world := testScene()
+	samples := box.samplePixel(10, 10, world, 0, 16)
+	if samples.weight != float64(samples.count) {
+		t.Errorf("expected the total weight to equal the sample count %d, got %v", samples.count, samples.weight)
+	}
+}
+
+func TestRenderer_SampleOffset_Strata(t *testing.T) {
+	for _, pattern := range []SamplePattern{SamplePatternStratified, SamplePatternStratifiedBlueNoise} {
+		opts := testOptions()
+		// A 3x3 grid, and a leftover sample.
+		opts.SamplesPerPixel, opts.SamplePattern = 10, pattern
+		rend := New(opts)
+
+		for _, pixel := range [][2]float64{{0, 0}, {5, 3}, {31, 23}} {
+			rng := rend.pixelGenerator(pixel[0], pixel[1], 0)
+			for sample := 0; sample < 9; sample++ {
+				offsetX, offsetY := rend.sampleOffset(pixel[0], pixel[1], sample, rng)
+				// Every sample stays in its own cell of the grid.
+				stratumX, stratumY := float64(sample%3), float64(sample/3)
+				if math.Floor(offsetX*3) != stratumX || math.Floor(offsetY*3) != stratumY {
+					t.Errorf("pattern %v, pixel %v: expected the sample %d in the stratum (%v, %v), got (%v, %v)",
+						pattern, pixel, sample, stratumX, stratumY, offsetX, offsetY)
+				}
+			}
+
+			if offsetX, offsetY := rend.sampleOffset(pixel[0], pixel[1], 9, rng); offsetX < 0 || offsetX >= 1 ||
+				offsetY < 0 || offsetY >= 1 {
+				t.Errorf("pattern %v, pixel %v: expected the leftover sample in the pixel, got (%v, %v)",
+					pattern, pixel, offsetX, offsetY)
+			}
+		}
+	}
+}
+
+func TestRotation(t *testing.T) {
+	// Neighbouring pixels get different rotations, which lie in the unit square.
+	for y := 0.0; y < 16; y++ {
+		for x := 0.0; x < 16; x++ {
+			rotX, rotY := rotation(x, y)
+			if rotX < 0 || rotX >= 1 || rotY < 0 || rotY >= 1 {
+				t.Fatalf("pixel (%v, %v): expected the rotation in the unit square, got (%v, %v)", x, y, rotX, rotY)
+			}
+
+			for _, neighbour := range [][2]float64{{x + 1, y}, {x, y + 1}, {x + 1, y + 1}} {
+				nx, ny := rotation(neighbour[0], neighbour[1])
+				if math.Abs(nx-rotX) < 1e-3 && math.Abs(ny-rotY) < 1e-3 {
+					t.Errorf("expected the pixels (%v, %v) and %v to have different rotations, both got (%v, %v)",
+						x, y, neighbour, rotX, rotY)
+				}
+			}
+		}
+	}
+
+	// The rotations are deterministic, and the same for all the samples of the pixel.
+	opts := testOptions()
+	opts.SamplesPerPixel, opts.SamplePattern = 4, SamplePatternStratifiedBlueNoise
+	rend := New(opts)
+	rotX, rotY := rotation(7, 9)
+	for sample := 0; sample < 4; sample++ {
+		offsetX, offsetY := rend.sampleOffset(7, 9, sample, rend.pixelGenerator(7, 9, sample))
+		wantX, wantY := (float64(sample%2)+rotX)/2, (float64(sample/2)+rotY)/2
+		if math.Abs(offsetX-wantX) > 1e-12 || math.Abs(offsetY-wantY) > 1e-12 {
+			t.Errorf("expected the sample %d at (%v, %v), got (%v, %v)", sample, wantX, wantY, offsetX, offsetY)
+		}
+	}
+}
+
+// rayRecorder is a material that records the rays that hit it, without scattering or emitting.
+type rayRecorder struct {
+	rays []*utils.Ray
+}
+
+func (r *rayRecorder) Scatter(*utils.Ray, *mats.RayHit, *random.Generator) (*utils.Ray, *utils.Colour, bool) {
+	return nil, nil, false
+}
+
+func (r *rayRecorder) Emit(ray *utils.Ray, _ *mats.RayHit) *utils.Colour {
+	r.rays = append(r.rays, ray)
+	return utils.NewColour(0, 0, 0)
+}
+
+func TestRenderer_DisableJitter(t *testing.T) {
+	// A wide lens and an open shutter, which randomize the rays unless the jitter is disabled.
+	opts := testOptions()
+	opts.Camera = camera.New(&camera.Options{
+		LookFrom:            utils.NewVec3(0, 0, 2),
+		LookAt:              utils.NewVec3(0, 0, -1),
+		Up:                  utils.NewVec3(0, 1, 0),
+		AspectRatio:         4.0 / 3,
+		FieldOfViewVertical: 40,
+		Aperture:            0.5,
+		AutoFocus:           true,
+		ShutterClose:        1,
+	})
+	opts.SamplesPerPixel = 8
+
+	// Every pattern places all samples at the pixel center.
+	for _, pattern := range []SamplePattern{SamplePatternRandom, SamplePatternStratified,
+		SamplePatternStratifiedBlueNoise} {
+		opts.SamplePattern, opts.DisableJitter = pattern, true
+		rend := New(opts)
+		for sample := 0; sample < opts.SamplesPerPixel; sample++ {
+			if x, y := rend.sampleOffset(5, 3, sample, rend.pixelGenerator(5, 3, 0)); x != 0.5 || y != 0.5 {
+				t.Errorf("pattern %v: expected the sample %d at the pixel center, got (%v, %v)", pattern, sample, x, y)
+			}
+		}
+	}
+
+	// The rays of the center pixel, recorded where they hit the sphere.
+	x, y := opts.ImageWidth/2, opts.ImageHeight/2
+	recordRays := func(disableJitter bool) []*utils.Ray {
+		recorder := &rayRecorder{}
+		world := shapes.NewSphere(utils.NewVec3(0, 0, -1), 0.5, recorder)
+
+		opts.SamplePattern, opts.DisableJitter = SamplePatternRandom, disableJitter
+		New(opts).samplePixel(x, y, world, 0, opts.SamplesPerPixel)
+		if len(recorder.rays) != opts.SamplesPerPixel {
+			t.Fatalf("expected all %d rays to hit the sphere, got %d", opts.SamplesPerPixel, len(recorder.rays))
+		}
+		return recorder.rays
+	}
+
+	// Without jitter, every sample is the same ray, from the lens center through the pixel center,
+	// at the shutter open time.
+	want := opts.Camera.CastCenterRay((x+0.5)/(opts.ImageWidth-1), (y+0.5)/(opts.ImageHeight-1))
+	for idx, ray := range recordRays(true) {
+		if !ray.Origin.ApproxEqual(want.Origin, 1e-12) || !ray.Dir.ApproxEqual(want.Dir, 1e-12) || ray.Time != 0 {
+			t.Errorf("sample %d: expected the center ray %v, got %v", idx, want, ray)
+		}
+	}
+
+	// With jitter, the samples differ.
+	rays := recordRays(false)
+	if rays[0].Origin.ApproxEqual(rays[1].Origin, 1e-6) || rays[0].Time == rays[1].Time {
+		t.Errorf("expected the jittered samples to differ, got %v and %v", rays[0], rays[1])
+	}
+}
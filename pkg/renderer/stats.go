@@ -0,0 +1,88 @@
+package renderer
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/shivanshkc/lightshow/pkg/shapes"
+)
+
+// Stats are the statistics of a render. They help in understanding the cost of a scene.
+type Stats struct {
+	// Primitives is the number of shapes in the world, with all groups and BVHs expanded.
+	Primitives int
+	// RaysCast is the total number of rays traced, including the scattered ones.
+	RaysCast int64
+	// SamplesTraced is the number of pixel samples traced. It falls short of the requested samples
+	// if the render is cut short by the MaxTotalRays.
+	SamplesTraced int64
+	// Duration of the render, including the encoding of the outputs.
+	Duration time.Duration
+	// Setup, Tracing and Encoding are the phases of the Duration. The Setup is the time spent in
+	// preparing the world, like building the BVH in RenderShapes. The Tracing is the time spent
+	// in rendering all pixels and passes, and the Encoding is the time spent in writing the outputs.
+	// Small leftovers, like validating the options, belong to none of them.
+	Setup, Tracing, Encoding time.Duration
+	// PeakMemory is the memory obtained from the OS by the Go runtime, in bytes.
+	// Since the runtime rarely returns memory, it approximates the peak usage.
+	PeakMemory uint64
+}
+
+// newStats returns the Stats for a render of the given world.
+func newStats(world shape, raysCast, samplesTraced int64, duration time.Duration) *Stats {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	return &Stats{
+		Primitives:    countPrimitives(world),
+		RaysCast:      raysCast,
+		SamplesTraced: samplesTraced,
+		Duration:      duration,
+		PeakMemory:    memStats.Sys,
+	}
+}
+
+// RaysPerSecond returns the average number of rays traced per second.
+func (s *Stats) RaysPerSecond() float64 {
+	if s.Duration <= 0 {
+		return 0
+	}
+	return float64(s.RaysCast) / s.Duration.Seconds()
+}
+
+func (s *Stats) String() string {
+	return fmt.Sprintf("Primitives: %d, samples traced: %d, rays cast: %d, rays per second: %.0f, duration: %s "+
+		"(setup: %s, tracing: %s, encoding: %s), peak memory: %.1f MiB",
+		s.Primitives, s.SamplesTraced, s.RaysCast, s.RaysPerSecond(), s.Duration.Round(time.Millisecond),
+		s.Setup.Round(time.Millisecond), s.Tracing.Round(time.Millisecond), s.Encoding.Round(time.Millisecond),
+		float64(s.PeakMemory)/(1<<20))
+}
+
+// Stats returns the statistics of the last render. It is nil if nothing is rendered yet.
+func (r *Renderer) Stats() *Stats {
+	return r.stats
+}
+
+// countPrimitives returns the number of shapes in the given world, with all groups and BVHs expanded.
+func countPrimitives(world shape) int {
+	if named, ok := world.(*shapes.NamedShape); ok {
+		return countPrimitives(named.Shape)
+	}
+
+	var members []shape
+	switch world := world.(type) {
+	case *shapes.Group:
+		members = world.Shapes
+	case *shapes.BVH:
+		members = world.Shapes()
+	default:
+		return 1
+	}
+
+	count := 0
+	for _, member := range members {
+		count += countPrimitives(member)
+	}
+	return count
+}
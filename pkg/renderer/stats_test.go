@@ -0,0 +1,88 @@
+package renderer_test
+
+import (
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/renderer"
+	"github.com/shivanshkc/lightshow/pkg/shapes"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestRenderer_Stats(t *testing.T) {
+	_, opts := tileTestScene(t)
+	width, height := int(opts.ImageWidth), int(opts.ImageHeight)
+	wantSamples := int64(width * height * opts.SamplesPerPixel)
+
+	// A BVH and a named shape nested in groups, all behind the camera, so that every camera ray misses.
+	sphere := func(x float64) shapes.Shape {
+		return shapes.NewSphere(utils.NewVec3(x, 0, 10), 0.5, mats.NewMatte(utils.NewColour(0.5, 0.5, 0.5)))
+	}
+	bvh := shapes.NewBVH(sphere(-3), sphere(-2), sphere(-1), sphere(0), sphere(1))
+	named := shapes.NewNamed("pair", shapes.NewGroup(sphere(2), sphere(3)))
+	world := shapes.NewGroup(shapes.NewGroup(bvh, named), sphere(4))
+
+	rend := renderer.New(opts)
+	if err := rend.Render(world); err != nil {
+		t.Fatalf("failed to render: %v", err)
+	}
+
+	stats := rend.Stats()
+	if stats.Primitives != 8 {
+		t.Errorf("expected 8 primitives, got %d", stats.Primitives)
+	}
+	if stats.SamplesTraced != wantSamples {
+		t.Errorf("expected %d samples traced, got %d", wantSamples, stats.SamplesTraced)
+	}
+	// Every sample is a single camera ray.
+	if stats.RaysCast != wantSamples {
+		t.Errorf("expected %d rays cast, got %d", wantSamples, stats.RaysCast)
+	}
+
+	// In front of the camera, the scattered rays are counted too.
+	visible, opts := tileTestScene(t)
+	rend = renderer.New(opts)
+	if err := rend.Render(visible); err != nil {
+		t.Fatalf("failed to render the visible scene: %v", err)
+	}
+
+	stats = rend.Stats()
+	if stats.Primitives != len(visible.Shapes) {
+		t.Errorf("expected %d primitives, got %d", len(visible.Shapes), stats.Primitives)
+	}
+	if stats.SamplesTraced != wantSamples {
+		t.Errorf("expected %d samples traced, got %d", wantSamples, stats.SamplesTraced)
+	}
+	if stats.RaysCast <= wantSamples {
+		t.Errorf("expected more rays cast than the %d samples, got %d", wantSamples, stats.RaysCast)
+	}
+}
+
+func TestRenderer_StatsPhases(t *testing.T) {
+	world, opts := tileTestScene(t)
+
+	// Enough shapes for RenderShapes to build a BVH, which is timed as the setup.
+	list := append([]shapes.Shape(nil), world.Shapes...)
+	for i := 0; i < 30; i++ {
+		x, z := float64(i%6)*0.3-0.75, -float64(i/6)*0.3-0.5
+		mat := mats.NewMatte(utils.NewColour(0.8, 0.2, 0.2))
+		list = append(list, shapes.NewSphere(utils.NewVec3(x, -0.4, z), 0.1, mat))
+	}
+
+	rend := renderer.New(opts)
+	if err := rend.RenderShapes(list); err != nil {
+		t.Fatalf("failed to render the shapes: %v", err)
+	}
+
+	stats := rend.Stats()
+	if stats.Setup <= 0 || stats.Tracing <= 0 || stats.Encoding <= 0 {
+		t.Errorf("expected all phases to be timed, got setup %v, tracing %v and encoding %v",
+			stats.Setup, stats.Tracing, stats.Encoding)
+	}
+
+	// The phases add up to about the Duration, with only small leftovers like validating the options.
+	sum := stats.Setup + stats.Tracing + stats.Encoding
+	if sum > stats.Duration || sum < stats.Duration*9/10 {
+		t.Errorf("expected the phases to add up to about the duration of %v, got %v", stats.Duration, sum)
+	}
+}
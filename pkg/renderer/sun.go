@@ -0,0 +1,54 @@
+package renderer
+
+import (
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// SunLight is a directional light, like the sun. All its rays are parallel and equally bright,
+// so it casts hard shadows and has no falloff with distance.
+//
+// It only lights the materials that implement mats.Diffuser, like Matte.
+type SunLight struct {
+	// Direction in which the light travels. For example, (0, -1, 0) is a sun right overhead.
+	Direction *utils.Vec3
+	// Colour of the light. A colour of 1 lights a white surface facing the sun at full brightness.
+	Colour *utils.Colour
+}
+
+// NewSunLight returns a new SunLight instance.
+func NewSunLight(direction *utils.Vec3, colour *utils.Colour) *SunLight {
+	return &SunLight{Direction: direction, Colour: colour}
+}
+
+// sunlight returns the light that the sun reflects toward the ray's origin, at the given point-of-hit.
+// It is black if there is no sun, the material is not diffuse, or the point is in shadow.
+func (r *Renderer) sunlight(ray *utils.Ray, hitInfo *mats.RayHit, world shape, ctx *pixelContext) *utils.Colour {
+	black := utils.NewColour(0, 0, 0)
+	if r.opts.Sun == nil {
+		return black
+	}
+
+	diffuser, ok := hitInfo.Mat.(mats.Diffuser)
+	if !ok {
+		return black
+	}
+
+	// The surface is lit according to Lambert's cosine law. To know more, visit-
+	// https://en.wikipedia.org/wiki/Lambert%27s_cosine_law
+	toSun := r.opts.Sun.Direction.Dir().Mul(-1)
+	cosine := hitInfo.Normal.Dot(toSun)
+	if cosine <= 0 {
+		return black
+	}
+
+	// Cast a shadow ray toward the sun. Any hit means that the point is in shadow.
+	ctx.rays++
+	shadowRay := utils.NewRay(hitInfo.Point, toSun)
+	shadowRay.Time = ray.Time
+	if _, isHit := world.Hit(shadowRay, r.surfaceInterval(hitInfo)); isHit {
+		return black
+	}
+
+	return diffuser.DiffuseAlbedo(hitInfo).Attenuate(r.opts.Sun.Colour).Scale(cosine)
+}
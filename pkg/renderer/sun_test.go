@@ -0,0 +1,63 @@
+package renderer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/shapes"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestRenderer_Sunlight(t *testing.T) {
+	// A floor facing up, with a ball of radius 0.5 hanging at a height of 1 above the origin.
+	floor := shapes.NewQuad(utils.NewVec3(-5, 0, -5), utils.NewVec3(0, 0, 10), utils.NewVec3(10, 0, 0),
+		mats.NewMatte(utils.NewColour(0.8, 0.8, 0.8)))
+	ball := shapes.NewSphere(utils.NewVec3(0, 1, 0), 0.5, mats.NewMatte(utils.NewColour(0.8, 0.8, 0.8)))
+	world := shapes.NewGroup(floor, ball)
+
+	// The sunlight at the floor point with the given x coordinate, seen from straight above.
+	sunlightAt := func(rend *Renderer, x float64) float64 {
+		ray := utils.NewRay(utils.NewVec3(x, 5, 0), utils.NewVec3(0, -1, 0))
+		hitInfo, isHit := floor.Hit(ray, rend.hitInterval())
+		if !isHit {
+			t.Fatalf("expected the ray at %v to hit the floor", x)
+		}
+		return rend.sunlight(ray, hitInfo, world, &pixelContext{rng: random.New(1)}).R
+	}
+
+	tests := []struct {
+		name      string
+		direction *utils.Vec3
+		// x coordinates of the floor points, and their expected sunlight.
+		points, want []float64
+	}{
+		// Overhead, the shadow is a disk of radius 0.5 under the ball. Its edge is hard, without any penumbra.
+		{name: "overhead", direction: utils.NewVec3(0, -1, 0),
+			points: []float64{0, 0.49, 0.51, 2}, want: []float64{0, 0, 0.8, 0.8}},
+		// At 45 degrees, the shadow moves by the height of the ball, and stretches to a half-width of 0.5 * √2.
+		// The lit floor gets the cosine of the angle.
+		{name: "slanted", direction: utils.NewVec3(1, -1, 0),
+			points: []float64{0, 0.3, 1, 1.7, 1.72}, want: []float64{0.8 / math.Sqrt2, 0, 0, 0, 0.8 / math.Sqrt2}},
+	}
+
+	for _, test := range tests {
+		opts := testOptions()
+		opts.Sun = NewSunLight(test.direction, utils.NewColour(1, 1, 1))
+		rend := New(opts)
+
+		for idx, x := range test.points {
+			if got := sunlightAt(rend, x); math.Abs(got-test.want[idx]) > 1e-9 {
+				t.Errorf("%s: expected the sunlight %v at %v, got %v", test.name, test.want[idx], x, got)
+			}
+		}
+	}
+
+	// A sun below the floor does not light it.
+	opts := testOptions()
+	opts.Sun = NewSunLight(utils.NewVec3(0, 1, 0), utils.NewColour(1, 1, 1))
+	if got := sunlightAt(New(opts), 2); got != 0 {
+		t.Errorf("expected no sunlight from below the floor, got %v", got)
+	}
+}
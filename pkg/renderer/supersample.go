@@ -0,0 +1,109 @@
+package renderer
+
+import (
+	"image"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// supersampled returns a copy of the renderer that renders at SupersampleFactor times the resolution.
+func (r *Renderer) supersampled() *Renderer {
+	factor := r.opts.SupersampleFactor
+
+	opts := *r.opts
+	opts.ImageWidth, opts.ImageHeight = r.opts.ImageWidth*float64(factor), r.opts.ImageHeight*float64(factor)
+	opts.Region = image.Rect(
+		r.opts.Region.Min.X*factor, r.opts.Region.Min.Y*factor,
+		r.opts.Region.Max.X*factor, r.opts.Region.Max.Y*factor,
+	)
+	opts.SupersampleFactor = 0
+	// The intermediate images would have the supersampled resolution.
+	opts.Progressive = false
+
+	// The ray counter is shared, so that the rays of the supersampled render are counted.
+	return &Renderer{opts: &opts, rays: r.rays, samples: r.samples}
+}
+
+// downsample converts the given supersampled pixels to the configured resolution.
+// Every final pixel is the average of the block of supersampled pixels that it covers.
+//
+// The gamma corrected pixels are averaged in linear space, which keeps the brightness of the edges correct.
+// It returns nil for nil pixels.
+func (r *Renderer) downsample(pixels []*utils.Colour) []*utils.Colour {
+	if pixels == nil {
+		return nil
+	}
+
+	factor := r.opts.SupersampleFactor
+	width, height := int(r.opts.ImageWidth), int(r.opts.ImageHeight)
+	srcWidth := width * factor
+
+	// Only the beauty mode output is gamma corrected.
+	gamma := r.opts.Mode.isGammaCorrected()
+
+	result := make([]*utils.Colour, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			// The pixels outside the region are not rendered. Since the region is scaled by the factor,
+			// they always form whole blocks, which are left transparent.
+			if pixels[y*factor*srcWidth+x*factor] == nil {
+				continue
+			}
+
+			sum := utils.NewColour(0, 0, 0)
+			for sy := y * factor; sy < (y+1)*factor; sy++ {
+				for sx := x * factor; sx < (x+1)*factor; sx++ {
+					pixel := pixels[sy*srcWidth+sx]
+					// Undo the gamma correction.
+					if gamma {
+						pixel = r.opts.OutputColourSpace.decode(pixel)
+					}
+					sum = sum.Add(pixel)
+				}
+			}
+
+			average := sum.DivScalar(float64(factor * factor))
+			if gamma {
+				average = r.opts.OutputColourSpace.encode(average)
+			}
+			result[y*width+x] = average
+		}
+	}
+
+	return result
+}
+
+// downsampleIDs is like downsample, but for the object-ID pass. Every final pixel takes the colour of
+// the supersampled pixel at the center of its block, since an average of ID colours would match no ID.
+// It returns nil for nil pixels.
+func (r *Renderer) downsampleIDs(pixels []*utils.Colour) []*utils.Colour {
+	if pixels == nil {
+		return nil
+	}
+
+	factor := r.opts.SupersampleFactor
+	width, height := int(r.opts.ImageWidth), int(r.opts.ImageHeight)
+	srcWidth := width * factor
+
+	result := make([]*utils.Colour, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			// The pixels outside the region are nil, and are left transparent.
+			result[y*width+x] = pixels[(y*factor+factor/2)*srcWidth+x*factor+factor/2]
+		}
+	}
+
+	return result
+}
+
+// downsampleLayers is like downsample, but for bounce layers. It returns nil for nil layers.
+func (r *Renderer) downsampleLayers(layers *bounceLayers) *bounceLayers {
+	if layers == nil {
+		return nil
+	}
+
+	return &bounceLayers{
+		direct:   r.downsample(layers.direct),
+		indirect: r.downsample(layers.indirect),
+	}
+}
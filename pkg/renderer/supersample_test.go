@@ -0,0 +1,54 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/shapes"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestRenderer_SupersampleFactor(t *testing.T) {
+	// A white disk on a black background, with a single ray per pixel, which gives jagged edges.
+	world := shapes.NewGroup(
+		shapes.NewSphere(utils.NewVec3(0, 0, -1), 0.6, mats.NewDiffuseLight(utils.NewColour(1, 1, 1))))
+	opts := straightOptions()
+	opts.Background = NewSolidBackground(utils.NewColour(0, 0, 0))
+	opts.SamplesPerPixel, opts.DisableJitter = 1, true
+
+	// The number of pixels that are neither black nor white, which only the edges can be.
+	countGreys := func(pixels []*utils.Colour) int {
+		var greys int
+		for _, pixel := range pixels {
+			if pixel.R > 0.01 && pixel.R < 0.99 {
+				greys++
+			}
+		}
+		return greys
+	}
+
+	rend := New(opts)
+	jagged, _, _ := rend.renderPasses(world)
+	if got := countGreys(jagged); got != 0 {
+		t.Fatalf("expected only black and white pixels without the supersampling, got %d greys", got)
+	}
+
+	opts.SupersampleFactor = 4
+	rend = New(opts)
+	supersampled, _, _ := rend.supersampled().renderPasses(world)
+	smooth := rend.downsample(supersampled)
+	if len(smooth) != len(jagged) {
+		t.Fatalf("expected the downsampled image to have %d pixels, got %d", len(jagged), len(smooth))
+	}
+
+	// The edge pixels get the partial coverage of the disk, while the inside and the outside stay as they were.
+	if got := countGreys(smooth); got < 20 {
+		t.Errorf("expected the supersampling to smooth the edges with grey pixels, got %d", got)
+	}
+	width, height := int(opts.ImageWidth), int(opts.ImageHeight)
+	for _, idx := range []int{0, height/2*width + width/2} {
+		if !smooth[idx].ApproxEqual(jagged[idx], 1e-9) {
+			t.Errorf("pixel %d: expected %v away from the edges, got %v", idx, jagged[idx], smooth[idx])
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package renderer
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"sync/atomic"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// RenderTile renders only the given region of the image and returns it as an 8-bit image,
+// whose bounds are the region (clipped to the image bounds), with top-left as the origin.
+// Nothing is written to the OutputFile.
+//
+// The random numbers of every pixel are derived from the Seed and the pixel's coordinates, so the
+// tiles of an image can be rendered separately (even on different machines) and stitched seamlessly
+// with StitchTiles. The result matches a full render, except with the options that look at the whole
+// image, like ImportanceSampling and ModeHeatMap.
+//
+// The MaxTotalRays applies to every tile separately. If a tile reaches it, the partial tile is returned
+// along with an error wrapping ErrRayLimitExceeded.
+func (r *Renderer) RenderTile(world shape, region image.Rectangle) (*image.RGBA, error) {
+	tiled := *r
+	opts := *r.opts
+	opts.Region = region
+	tiled.opts = &opts
+	// Every tile counts its own rays, so that the tiles do not use up each other's ray limit.
+	tiled.rays, tiled.samples = &atomic.Int64{}, &atomic.Int64{}
+
+	region = tiled.region()
+	if region.Empty() {
+		return nil, fmt.Errorf("tile %v does not overlap the image", opts.Region)
+	}
+
+	var pixels []*utils.Colour
+	if r.opts.SupersampleFactor > 1 {
+		pixels, _, _ = tiled.supersampled().renderPasses(world)
+		pixels = tiled.downsample(pixels)
+	} else {
+		pixels, _, _ = tiled.renderPasses(world)
+	}
+
+	width := int(r.opts.ImageWidth)
+	tile := image.NewRGBA(region)
+	for y := region.Min.Y; y < region.Max.Y; y++ {
+		for x := region.Min.X; x < region.Max.X; x++ {
+			tile.Set(x, y, pixels[y*width+x].ToStd())
+		}
+	}
+
+	if tiled.rayLimitReached() {
+		return tile, fmt.Errorf("%w: %d rays cast with a limit of %d, the tile is partial",
+			ErrRayLimitExceeded, tiled.rays.Load(), r.opts.MaxTotalRays)
+	}
+
+	return tile, nil
+}
+
+// StitchTiles composites the given tiles, as returned by RenderTile, into a full image of the given size.
+// The parts of the image that are not covered by any tile are left transparent.
+func StitchTiles(width, height int, tiles ...*image.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for _, tile := range tiles {
+		draw.Draw(img, tile.Bounds(), tile, tile.Bounds().Min, draw.Src)
+	}
+
+	return img
+}
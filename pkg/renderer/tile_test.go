@@ -0,0 +1,173 @@
+package renderer_test
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/camera"
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/renderer"
+	"github.com/shivanshkc/lightshow/pkg/shapes"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// tileTestScene returns a small scene, and the options to render it with a fixed seed.
+func tileTestScene(t *testing.T) (*shapes.Group, *renderer.Options) {
+	t.Helper()
+
+	world := shapes.NewGroup(
+		shapes.NewSphere(utils.NewVec3(0, -100.5, -1), 100, mats.NewMatte(utils.NewColour(0.8, 0.8, 0))),
+		shapes.NewSphere(utils.NewVec3(0, 0, -1), 0.5, mats.NewMatte(utils.NewColour(0.1, 0.2, 0.5))),
+		shapes.NewSphere(utils.NewVec3(1, 0, -1), 0.5, mats.NewGlass(1.5)),
+	)
+
+	opts := &renderer.Options{
+		Camera: camera.New(&camera.Options{
+			LookFrom:            utils.NewVec3(0, 0.5, 2),
+			LookAt:              utils.NewVec3(0, 0, -1),
+			Up:                  utils.NewVec3(0, 1, 0),
+			AspectRatio:         1.5,
+			FieldOfViewVertical: 40,
+			Aperture:            0.05,
+			AutoFocus:           true,
+		}),
+		ImageWidth:        30,
+		ImageHeight:       20,
+		SkyColour:         utils.NewColour(0.5, 0.7, 1),
+		MaxDiffusionDepth: 8,
+		SamplesPerPixel:   4,
+		MaxWorkers:        4,
+		Seed:              7,
+		OutputFile:        filepath.Join(t.TempDir(), "full.png"),
+	}
+
+	return world, opts
+}
+
+func TestRenderTile(t *testing.T) {
+	world, opts := tileTestScene(t)
+	width, height := int(opts.ImageWidth), int(opts.ImageHeight)
+	rend := renderer.New(opts)
+
+	if err := rend.Render(world); err != nil {
+		t.Fatalf("failed to render: %v", err)
+	}
+	full := decodePNG(t, opts.OutputFile)
+
+	// Four uneven tiles that cover the image.
+	regions := []image.Rectangle{
+		image.Rect(0, 0, 13, 9), image.Rect(13, 0, width, 9),
+		image.Rect(0, 9, 13, height), image.Rect(13, 9, width, height),
+	}
+
+	var tiles []*image.RGBA
+	for _, region := range regions {
+		tile, err := rend.RenderTile(world, region)
+		if err != nil {
+			t.Fatalf("failed to render tile %v: %v", region, err)
+		}
+		tiles = append(tiles, tile)
+	}
+	stitched := renderer.StitchTiles(width, height, tiles...)
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if stitched.At(x, y) != full.At(x, y) {
+				t.Fatalf("pixel (%d, %d) differs: stitched %v, full %v", x, y, stitched.At(x, y), full.At(x, y))
+			}
+		}
+	}
+}
+
+func TestRender_Region(t *testing.T) {
+	world, opts := tileTestScene(t)
+	if err := renderer.New(opts).Render(world); err != nil {
+		t.Fatalf("failed to render: %v", err)
+	}
+	full := decodePNG(t, opts.OutputFile)
+
+	region := image.Rect(7, 4, 19, 15)
+	opts.Region = region
+	opts.OutputFile = filepath.Join(t.TempDir(), "region.png")
+	if err := renderer.New(opts).Render(world); err != nil {
+		t.Fatalf("failed to render the region: %v", err)
+	}
+	partial := decodePNG(t, opts.OutputFile)
+
+	if partial.Bounds() != full.Bounds() {
+		t.Fatalf("expected the image to keep its bounds %v, got %v", full.Bounds(), partial.Bounds())
+	}
+	for y := 0; y < int(opts.ImageHeight); y++ {
+		for x := 0; x < int(opts.ImageWidth); x++ {
+			got := color.RGBAModel.Convert(partial.At(x, y))
+			// The pixels in the region match the full render, and the others are left transparent.
+			want := color.RGBAModel.Convert(full.At(x, y))
+			if !image.Pt(x, y).In(region) {
+				want = color.RGBA{}
+			}
+			if got != want {
+				t.Fatalf("pixel (%d, %d): expected %v, got %v", x, y, want, got)
+			}
+		}
+	}
+}
+
+func TestRenderTile_RayLimit(t *testing.T) {
+	world, opts := tileTestScene(t)
+	// Enough for about half of a tile.
+	opts.MaxTotalRays = 1000
+	rend := renderer.New(opts)
+
+	// Every tile has its own limit, so the second tile is not cut short by the rays of the first one.
+	for _, region := range []image.Rectangle{image.Rect(0, 0, 15, 20), image.Rect(15, 0, 30, 20)} {
+		tile, err := rend.RenderTile(world, region)
+		if !errors.Is(err, renderer.ErrRayLimitExceeded) {
+			t.Fatalf("expected ErrRayLimitExceeded for tile %v, got %v", region, err)
+		}
+		if tile == nil || tile.Bounds() != region {
+			t.Fatalf("expected the partial tile %v, got %v", region, tile)
+		}
+		// The first pixels are rendered before the limit is reached.
+		if tile.RGBAAt(region.Min.X, region.Min.Y) == (color.RGBA{}) {
+			t.Fatalf("expected the first pixel of tile %v to be rendered", region)
+		}
+	}
+
+	// The samples skipped after the limit are not counted as traced, so every counted sample cast a ray.
+	opts.Region = image.Rect(0, 0, 15, 20)
+	rend = renderer.New(opts)
+	if err := rend.Render(world); !errors.Is(err, renderer.ErrRayLimitExceeded) {
+		t.Fatalf("expected ErrRayLimitExceeded for the region, got %v", err)
+	}
+	stats, requested := rend.Stats(), int64(15*20*opts.SamplesPerPixel)
+	if stats.SamplesTraced <= 0 || stats.SamplesTraced >= requested {
+		t.Fatalf("expected fewer than the %d requested samples to be traced, got %d", requested, stats.SamplesTraced)
+	}
+	if stats.RaysCast < stats.SamplesTraced {
+		t.Fatalf("expected at least one ray per traced sample, got %d rays for %d samples",
+			stats.RaysCast, stats.SamplesTraced)
+	}
+}
+
+// decodePNG decodes the PNG file at the given path.
+func decodePNG(t *testing.T, path string) image.Image {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	img, err := png.Decode(file)
+	if err != nil {
+		t.Fatalf("failed to decode %s: %v", path, err)
+	}
+
+	return img
+}
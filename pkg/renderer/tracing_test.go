@@ -0,0 +1,324 @@
+package renderer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/camera"
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/shapes"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// testScene returns a small scene with diffuse, metallic, glass and emissive materials.
+func testScene() shape {
+	return shapes.NewGroup(
+		shapes.NewSphere(utils.NewVec3(0, -100.5, -1), 100, mats.NewMatte(utils.NewColour(0.8, 0.8, 0))),
+		shapes.NewSphere(utils.NewVec3(0, 0, -1), 0.5, mats.NewMatte(utils.NewColour(0.1, 0.2, 0.5))),
+		shapes.NewSphere(utils.NewVec3(-1, 0, -1), 0.5, mats.NewGlass(1.5)),
+		shapes.NewSphere(utils.NewVec3(1, 0, -1), 0.5, mats.NewMetallic(utils.NewColour(0.8, 0.6, 0.2), 0.1)),
+		shapes.NewSphere(utils.NewVec3(0, 1.5, -1), 0.3, mats.NewDiffuseLight(utils.NewColour(4, 4, 4))),
+	)
+}
+
+// testOptions returns the options for rendering the testScene at a small resolution, with a fixed seed.
+func testOptions() *Options {
+	cam := camera.New(&camera.Options{
+		LookFrom:            utils.NewVec3(0, 0.5, 2),
+		LookAt:              utils.NewVec3(0, 0, -1),
+		Up:                  utils.NewVec3(0, 1, 0),
+		AspectRatio:         4.0 / 3,
+		FieldOfViewVertical: 40,
+		AutoFocus:           true,
+	})
+
+	return &Options{
+		Camera:            cam,
+		ImageWidth:        32,
+		ImageHeight:       24,
+		SkyColour:         utils.NewColour(0.5, 0.7, 1),
+		MaxDiffusionDepth: 8,
+		SamplesPerPixel:   4,
+		MaxWorkers:        4,
+		Seed:              42,
+	}
+}
+
+func TestRenderer_Iterative(t *testing.T) {
+	world := testScene()
+
+	recursive, _, _ := New(testOptions()).renderPasses(world)
+
+	opts := testOptions()
+	opts.Iterative = true
+	iterative, _, _ := New(opts).renderPasses(world)
+
+	// The tracers add up the same terms in a different order, so tiny floating-point differences are expected.
+	for idx := range recursive {
+		if !recursive[idx].ApproxEqual(iterative[idx], 1e-9) {
+			t.Fatalf("pixel %d differs: recursive %v, iterative %v", idx, recursive[idx], iterative[idx])
+		}
+	}
+}
+
+func TestRenderer_Seed(t *testing.T) {
+	world := testScene()
+
+	// Many workers share the scene, which the race detector checks when run with -race.
+	opts := testOptions()
+	opts.MaxWorkers = 16
+	first, _, _ := New(opts).renderPasses(world)
+	second, _, _ := New(opts).renderPasses(world)
+
+	// The number of workers must not affect the image either.
+	opts.MaxWorkers = 1
+	serial, _, _ := New(opts).renderPasses(world)
+
+	for idx := range first {
+		if *first[idx] != *second[idx] {
+			t.Fatalf("pixel %d differs between renders: %v and %v", idx, first[idx], second[idx])
+		}
+		if *first[idx] != *serial[idx] {
+			t.Fatalf("pixel %d differs with a single worker: %v and %v", idx, first[idx], serial[idx])
+		}
+	}
+}
+
+func TestRenderer_ShadowEpsilon(t *testing.T) {
+	// A diffuse sphere so large that rounding errors on its surface exceed the default epsilon.
+	const scale = 1e13
+	world := shapes.NewSphere(utils.NewVec3(0, -scale, 0), scale, mats.NewMatte(utils.NewColour(0.5, 0.5, 0.5)))
+
+	// With a white background and two bounces, a scattered ray that escapes lights the pixel.
+	// Only a ray that hits the (convex) sphere again, which is acne, leaves it black.
+	blackPixels := func(epsilon float64) int {
+		cam := camera.New(&camera.Options{
+			LookFrom:            utils.NewVec3(0, scale/100, scale/20),
+			LookAt:              utils.NewVec3(0, 0, 0),
+			Up:                  utils.NewVec3(0, 1, 0),
+			AspectRatio:         1,
+			FieldOfViewVertical: 30,
+			FocusDistance:       1,
+		})
+
+		pixels, _, _ := New(&Options{
+			Camera:            cam,
+			ImageWidth:        40,
+			ImageHeight:       40,
+			Background:        NewSolidBackground(utils.NewColour(1, 1, 1)),
+			MaxDiffusionDepth: 2,
+			SamplesPerPixel:   1,
+			DisableJitter:     true,
+			MaxWorkers:        4,
+			ShadowEpsilon:     epsilon,
+		}).renderPasses(world)
+
+		var count int
+		for _, pixel := range pixels {
+			if pixel.R == 0 {
+				count++
+			}
+		}
+		return count
+	}
+
+	if count := blackPixels(0); count == 0 {
+		t.Fatal("expected the default epsilon to leave acne at this scale")
+	}
+	if count := blackPixels(scale * 1e-3); count != 0 {
+		t.Fatalf("expected a scaled epsilon to remove the acne, got %d black pixels", count)
+	}
+}
+
+func TestRenderer_Bias(t *testing.T) {
+	// A giant ground, whose rounding errors exceed the default epsilon, and a bead smaller than it.
+	const scale = 1e13
+	ground := shapes.NewSphere(utils.NewVec3(0, -scale, 0), scale, nil)
+	ground.Bias = scale * 1e-3
+	bead := shapes.NewSphere(utils.NewVec3(0, 1, 0), 1e-5, nil)
+	bead.Bias = 1e-9
+	world := shapes.NewGroup(ground, bead)
+
+	rend := New(testOptions())
+	rng := random.New(6)
+	const rays = 1000
+
+	// The rays scattered off the (convex) ground can only hit it again because of acne.
+	var groundAcne, groundBiasedAcne int
+	lookFrom := utils.NewVec3(0, scale/100, scale/20)
+	for i := 0; i < rays; i++ {
+		target := utils.NewVec3(rng.FloatBetween(-1, 1)*scale/100, 0, rng.FloatBetween(-1, 1)*scale/100)
+		hit, isHit := ground.Hit(utils.NewRay(lookFrom, target.Sub(lookFrom)), rend.hitInterval())
+		if !isHit {
+			t.Fatalf("expected the ray toward %v to hit the ground", target)
+		}
+
+		scattered := utils.NewRay(hit.Point, hit.Normal.Add(rng.UnitVec3()))
+		if _, isHit := world.Hit(scattered, rend.hitInterval()); isHit {
+			groundAcne++
+		}
+		if _, isHit := world.Hit(scattered, rend.surfaceInterval(hit)); isHit {
+			groundBiasedAcne++
+		}
+	}
+
+	// The rays refracted into the bead must hit its far side, which is closer than the default epsilon.
+	var beadLeaks, beadBiasedLeaks int
+	for i := 0; i < rays; i++ {
+		origin := bead.Center.Add(rng.UnitVec3())
+		hit, isHit := bead.Hit(utils.NewRay(origin, bead.Center.Sub(origin)), rend.hitInterval())
+		if !isHit {
+			t.Fatalf("expected the ray from %v to hit the bead", origin)
+		}
+
+		// Anything hit farther than the diameter of the bead is outside it.
+		refracted := utils.NewRay(hit.Point, hit.Normal.Mul(-1).Add(rng.UnitVec3().Mul(0.5)))
+		if farSide, isHit := world.Hit(refracted, rend.hitInterval()); !isHit || farSide.Distance > 2*bead.Radius {
+			beadLeaks++
+		}
+		if farSide, isHit := world.Hit(refracted, rend.surfaceInterval(hit)); !isHit || farSide.Distance > 2*bead.Radius {
+			beadBiasedLeaks++
+		}
+	}
+
+	if groundAcne == 0 || beadLeaks != rays {
+		t.Fatalf("expected the default epsilon to fail on both scales, got %d acne hits on the ground and "+
+			"%d of %d rays leaking out of the bead", groundAcne, beadLeaks, rays)
+	}
+	if groundBiasedAcne != 0 || beadBiasedLeaks != 0 {
+		t.Errorf("expected the biases to fix both scales, got %d acne hits on the ground and "+
+			"%d rays leaking out of the bead", groundBiasedAcne, beadBiasedLeaks)
+	}
+}
+
+// sequenceEmitter is a material that emits the given colours in turn, one per hit, without scattering.
+type sequenceEmitter struct {
+	emissions []*utils.Colour
+	next      int
+}
+
+func (s *sequenceEmitter) Scatter(*utils.Ray, *mats.RayHit, *random.Generator) (*utils.Ray, *utils.Colour, bool) {
+	return nil, nil, false
+}
+
+func (s *sequenceEmitter) Emit(*utils.Ray, *mats.RayHit) *utils.Colour {
+	emission := s.emissions[s.next%len(s.emissions)]
+	s.next++
+	return emission
+}
+
+func TestRenderer_FireflyClamp(t *testing.T) {
+	// Every sample of the pixel hits the emitter, so it gets an invalid, an infinite, a huge and a normal sample.
+	emitter := &sequenceEmitter{emissions: []*utils.Colour{
+		utils.NewColour(math.NaN(), 1, 1),
+		utils.NewColour(math.Inf(1), 1, 1),
+		utils.NewColour(1e12, 1e12, 1e12),
+		utils.NewColour(0.5, 0.5, 0.5),
+	}}
+	world := shapes.NewSphere(utils.NewVec3(0, 0, -1), 0.5, emitter)
+
+	opts := testOptions()
+	opts.SamplesPerPixel = len(emitter.emissions)
+	opts.DisableJitter = true
+	opts.FireflyClamp = 2
+
+	r := New(opts)
+	x, y := float64(opts.ImageWidth)/2, float64(opts.ImageHeight)/2
+	samples := r.samplePixel(x, y, world, 0, opts.SamplesPerPixel)
+
+	// The invalid samples are skipped, and the huge one is clamped, so the average is that of 2 and 0.5.
+	average := samples.sum.DivScalar(samples.weight)
+	if !average.ApproxEqual(utils.NewColour(1.25, 1.25, 1.25), 1e-9) {
+		t.Fatalf("expected the average of the clamped and normal samples, got %v", average)
+	}
+}
+
+func TestRenderer_HeatMap(t *testing.T) {
+	opts := testOptions()
+	opts.Mode = ModeHeatMap
+	pixels, _, _ := New(opts).renderPasses(testScene())
+
+	// position returns the position of the given colour on the heat map ramp, from 0 for blue to 1 for red.
+	position := func(colour *utils.Colour) float64 {
+		best, bestDistance := 0.0, math.Inf(1)
+		for i := 0; i+1 < len(heatColours); i++ {
+			for step := 0; step <= 100; step++ {
+				fraction := float64(step) / 100
+				distance := heatColours[i].Lerp(heatColours[i+1], fraction).ToVec3().Sub(colour.ToVec3()).Mag()
+				if distance < bestDistance {
+					best, bestDistance = (float64(i)+fraction)/float64(len(heatColours)-1), distance
+				}
+			}
+		}
+		return best
+	}
+
+	width := int(opts.ImageWidth)
+	// The top-left pixel sees only the sky, while the center one sees the diffuse spheres, whose rays bounce.
+	sky, geometry := position(pixels[0]), position(pixels[12*width+16])
+	if geometry <= sky {
+		t.Fatalf("expected the geometry to be hotter than the sky, got %v and %v", geometry, sky)
+	}
+
+	// A sky pixel costs a single ray per sample, which is the least that any pixel can cost.
+	for idx, pixel := range pixels {
+		if position(pixel) < sky-0.01 {
+			t.Fatalf("expected pixel %d to be at least as hot as the sky, got %v and %v", idx, position(pixel), sky)
+		}
+	}
+}
+
+// BenchmarkRenderer_RenderPixel traces single samples of the testScene through renderPixel and traceRay,
+// and reports the allocations per sample, which dominate the garbage collection work of a render.
+// Run it with -memprofile to see where they come from:
+//
+//	go test ./pkg/renderer -run '^$' -bench RenderPixel -memprofile mem.prof
+//	go tool pprof -sample_index=alloc_objects mem.prof
+func BenchmarkRenderer_RenderPixel(b *testing.B) {
+	world, opts := testScene(), testOptions()
+	rend := New(opts)
+	ctx := &pixelContext{rng: random.New(opts.Seed)}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x := float64(i % int(opts.ImageWidth))
+		y := float64(i / int(opts.ImageWidth) % int(opts.ImageHeight))
+		rend.renderPixel(x, y, world, ctx)
+	}
+
+	b.ReportMetric(float64(ctx.rays)/float64(b.N), "rays/op")
+}
+
+func TestRenderer_QueueSize(t *testing.T) {
+	opts := testOptions()
+	opts.MaxWorkers, opts.QueueSize = 2, 3
+	rend := New(opts)
+
+	// Tasks that block until released occupy the workers and fill the queue, after which no more are accepted.
+	pool := rend.newWorkerPool()
+	release := make(chan struct{})
+	var accepted int
+	for accepted <= 100 && pool.TrySubmit(func() { <-release }) {
+		accepted++
+	}
+	close(release)
+	pool.StopAndWait()
+
+	// The workers may not have picked up their tasks yet, in which case the tasks are still queued.
+	if accepted < opts.QueueSize || accepted > opts.MaxWorkers+opts.QueueSize {
+		t.Errorf("expected between %d and %d tasks in flight, got %d",
+			opts.QueueSize, opts.MaxWorkers+opts.QueueSize, accepted)
+	}
+
+	// A render with the small queue still completes, with the same image as with the default queue.
+	world := testScene()
+	small, _, _ := rend.renderPasses(world)
+	defaultQueue, _, _ := New(testOptions()).renderPasses(world)
+	for idx := range small {
+		if *small[idx] != *defaultQueue[idx] {
+			t.Fatalf("pixel %d differs with the small queue: %v and %v", idx, small[idx], defaultQueue[idx])
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package scenes
+
+import (
+	"github.com/shivanshkc/lightshow/pkg/camera"
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/renderer"
+	"github.com/shivanshkc/lightshow/pkg/shapes"
+	"github.com/shivanshkc/lightshow/pkg/textures"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// Scene is a ready-to-render set of shapes, along with the settings that suit it.
+type Scene struct {
+	// World holds all the shapes of the scene. More shapes can be added to it.
+	World *shapes.Group
+	// Lights are the light sources of the scene, which are also present in the World.
+	// The renderer finds them by tracing rays, so they are listed only for the callers that sample them directly.
+	Lights []shapes.Light
+	// Background is the suggested background of the scene.
+	Background renderer.Background
+	// Camera holds the suggested camera options. They can be modified before creating the camera.
+	Camera *camera.Options
+}
+
+// Studio returns a scene for product shots. The subject should be added to the World,
+// resting on the ground near the origin and roughly within a unit radius.
+//
+// It has a neutral checkered ground with unit-sized cells, a neutral gradient sky and three-point lighting,
+// which consists of a bright key light, a dimmer fill light on the opposite side and a rim light behind the subject.
+func Studio(aspectRatio float64) *Scene {
+	subject := utils.NewVec3(0, 0.5, 0)
+
+	// The lights face the subject.
+	keyLight := facingLight(utils.NewVec3(3, 4, 3), subject, 2, utils.NewColour(6, 6, 5.5))
+	fillLight := facingLight(utils.NewVec3(-4, 2, 2), subject, 2, utils.NewColour(1.5, 1.5, 1.7))
+	rimLight := facingLight(utils.NewVec3(0, 5, -4), subject, 1.5, utils.NewColour(4, 4, 4))
+
+	// The ground is large enough to fill the view of the suggested camera.
+	// It has as many cells as its size, so that every cell is a unit square, which helps in judging the scale.
+	const groundSize = 100
+	checker := textures.NewChecker(utils.NewColour(0.6, 0.6, 0.6), utils.NewColour(0.45, 0.45, 0.45), groundSize)
+	ground := shapes.NewQuad(
+		utils.NewVec3(-groundSize/2, 0, -groundSize/2), utils.NewVec3(0, 0, groundSize), utils.NewVec3(groundSize, 0, 0),
+		mats.NewTexturedMatte(checker),
+	)
+
+	return &Scene{
+		World:      shapes.NewGroup(ground, keyLight, fillLight, rimLight),
+		Lights:     []shapes.Light{keyLight, fillLight, rimLight},
+		Background: renderer.NewGradientBackground(utils.NewColour(0.3, 0.3, 0.32), utils.NewColour(0.1, 0.1, 0.1)),
+		Camera: &camera.Options{
+			LookFrom:            utils.NewVec3(0, 1.5, 6),
+			LookAt:              subject,
+			Up:                  utils.NewVec3(0, 1, 0),
+			AspectRatio:         aspectRatio,
+			FieldOfViewVertical: 30,
+			AutoFocus:           true,
+		},
+	}
+}
+
+// facingLight returns a square area light of the given size, centered at the given position
+// and emitting toward the given target.
+func facingLight(center, target *utils.Vec3, size float64, emission *utils.Colour) *shapes.Quad {
+	// The edges are chosen so that their cross product (the front face) points toward the target.
+	uEdge, vEdge := target.Sub(center).Dir().OrthonormalBasis()
+	uEdge, vEdge = uEdge.Mul(size), vEdge.Mul(size)
+
+	corner := center.Sub(uEdge.Div(2)).Sub(vEdge.Div(2))
+	return shapes.NewAreaLight(corner, uEdge, vEdge, emission)
+}
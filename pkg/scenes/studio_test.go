@@ -0,0 +1,32 @@
+package scenes
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/camera"
+	"github.com/shivanshkc/lightshow/pkg/renderer"
+)
+
+func TestStudio(t *testing.T) {
+	scene := Studio(16.0 / 9)
+
+	// Key, fill and rim lights.
+	if len(scene.Lights) != 3 {
+		t.Fatalf("expected 3 lights, got %d", len(scene.Lights))
+	}
+
+	err := renderer.New(&renderer.Options{
+		Camera:            camera.New(scene.Camera),
+		ImageWidth:        32,
+		ImageHeight:       18,
+		Background:        scene.Background,
+		MaxDiffusionDepth: 4,
+		SamplesPerPixel:   1,
+		MaxWorkers:        4,
+		OutputFile:        filepath.Join(t.TempDir(), "studio.png"),
+	}).Render(scene.World)
+	if err != nil {
+		t.Fatalf("failed to render the scene: %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package shapes
+
+import (
+	"image"
+	"sync/atomic"
+
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/textures"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// Billboard is a flat, image-textured quad, for cheap sprites like vegetation. It implements the Shape interface.
+//
+// The image is stretched over the quad with its top-left corner at Q + V, and its bottom-left corner at Q.
+// Rays through the transparent parts of the image pass through, as if the billboard were not there.
+// The opaque parts appear as a matte surface with the colour of the image.
+//
+// The billboard has a fixed orientation. To face the camera, its U and V edges should be
+// perpendicular to the viewing direction.
+type Billboard struct {
+	// Q is the position vector of the bottom-left corner of the billboard.
+	Q *utils.Vec3
+	// U and V are the horizontal and vertical edges of the billboard that start at Q.
+	U, V *utils.Vec3
+
+	// Image shown on the billboard. Its alpha channel determines the transparent parts.
+	// It should not be changed once the billboard has been hit.
+	Image image.Image
+	// AlphaCutoff is the alpha, in the [0, 1] interval, below which the image is considered transparent.
+	// It defaults to 0.5.
+	AlphaCutoff float64
+
+	// ID is an optional identifier of the billboard, used for the object-ID pass.
+	ID int
+
+	// surface is the texture and the material of the image, created on the first hit.
+	// All hits share the material, so that they are recognized as the same surface, like by the edge detection.
+	surface atomic.Pointer[billboardSurface]
+}
+
+// billboardSurface is the appearance of a Billboard.
+type billboardSurface struct {
+	texture *textures.Image
+	mat     *mats.Matte
+}
+
+// NewBillboard returns a new Billboard.
+func NewBillboard(q, u, v *utils.Vec3, img image.Image) *Billboard {
+	return &Billboard{Q: q, U: u, V: v, Image: img}
+}
+
+func (b *Billboard) Hit(ray *utils.Ray, interval utils.Interval) (*mats.RayHit, bool) {
+	quad := Quad{Q: b.Q, U: b.U, V: b.V, ID: b.ID}
+	rayHit, isHit := quad.Hit(ray, interval)
+	if !isHit {
+		return nil, false
+	}
+
+	// The quad is flat, so a ray through a transparent texel cannot hit it anywhere else.
+	surface := b.getSurface()
+	cutoff := b.AlphaCutoff
+	if cutoff <= 0 {
+		cutoff = 0.5
+	}
+	if surface.texture.Alpha(rayHit.U, rayHit.V) < cutoff {
+		return nil, false
+	}
+
+	rayHit.Mat = surface.mat
+	return rayHit, true
+}
+
+func (b *Billboard) BoundingBox() utils.AABB {
+	return parallelogramBox(b.Q, b.U, b.V)
+}
+
+// getSurface returns the surface of the billboard, creating it if this is the first call.
+// It is safe for concurrent use.
+func (b *Billboard) getSurface() *billboardSurface {
+	if surface := b.surface.Load(); surface != nil {
+		return surface
+	}
+
+	texture := &textures.Image{Image: b.Image, Wrap: textures.WrapClamp}
+	surface := &billboardSurface{texture: texture, mat: mats.NewTexturedMatte(texture)}
+	// Concurrent first calls may all create a surface, but only the first one is kept.
+	if !b.surface.CompareAndSwap(nil, surface) {
+		return b.surface.Load()
+	}
+	return surface
+}
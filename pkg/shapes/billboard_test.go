@@ -0,0 +1,47 @@
+package shapes
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestBillboard_Hit(t *testing.T) {
+	// A 2x2 image whose top-left texel is transparent, and the others are opaque red.
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	for _, texel := range []image.Point{{X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}} {
+		img.SetNRGBA(texel.X, texel.Y, color.NRGBA{R: 255, A: 255})
+	}
+
+	billboard := NewBillboard(utils.NewVec3(0, 0, 0), utils.NewVec3(2, 0, 0), utils.NewVec3(0, 2, 0), img)
+	behind := NewSphere(utils.NewVec3(0.5, 1.5, -3), 0.5, nil)
+	world := NewGroup(billboard, behind)
+	interval := utils.NewInterval(0, math.MaxFloat64)
+
+	// The rays through the opaque texels hit the billboard, which has the colour of the image.
+	for _, point := range [][2]float64{{1.5, 1.5}, {0.5, 0.5}, {1.5, 0.5}} {
+		ray := utils.NewRay(utils.NewVec3(point[0], point[1], 5), utils.NewVec3(0, 0, -1))
+		hit, isHit := world.Hit(ray, interval)
+		if !isHit || hit.Distance != 5 {
+			t.Fatalf("expected the ray through %v to hit the billboard, got %v", point, hit)
+		}
+
+		albedo := hit.Mat.(mats.Diffuser).DiffuseAlbedo(hit)
+		if !albedo.ApproxEqual(utils.NewColour(1, 0, 0), 1e-9) {
+			t.Errorf("expected the billboard at %v to be red, got %v", point, albedo)
+		}
+	}
+
+	// The ray through the transparent texel passes through, and hits the sphere behind it.
+	ray := utils.NewRay(utils.NewVec3(0.5, 1.5, 5), utils.NewVec3(0, 0, -1))
+	if _, isHit := billboard.Hit(ray, interval); isHit {
+		t.Errorf("expected the ray through the transparent texel to miss the billboard")
+	}
+	if hit, isHit := world.Hit(ray, interval); !isHit || math.Abs(hit.Distance-7.5) > 1e-9 {
+		t.Errorf("expected the ray through the transparent texel to hit the sphere behind, got %v", hit)
+	}
+}
@@ -0,0 +1,169 @@
+package shapes
+
+import (
+	"sort"
+
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// BVH is a bounding volume hierarchy over a list of shapes. It implements the Shape interface.
+//
+// It returns the same hits as a Group of the same shapes, but a ray only tests the shapes whose
+// bounding boxes it passes through, which makes it much faster for large lists.
+//
+// It is built once, so the shapes must not be moved or changed after it is created.
+// To know more, visit-
+// https://raytracing.github.io/books/RayTracingTheNextWeek.html#boundingvolumehierarchies
+type BVH struct {
+	// shapes holds all the shapes of the hierarchy, in the given order.
+	shapes []Shape
+	// root is the topmost node of the hierarchy.
+	root *bvhNode
+}
+
+// bvhNode is a node of a BVH. Its children are either shapes, for the leaves, or other nodes.
+type bvhNode struct {
+	// left and right are the children of the node. The right child is nil if the node has a single shape.
+	left, right Shape
+	// box contains both the children.
+	box utils.AABB
+}
+
+// bvhItem is a shape being sorted into a BVH, along with its bounding box.
+type bvhItem struct {
+	shape  Shape
+	box    utils.AABB
+	center *utils.Vec3
+}
+
+// NewBVH builds a BVH over the given shapes, which must not be empty.
+//
+// The shapes are split using the surface area heuristic (SAH), which minimizes the expected cost of
+// hitting the two halves of every node. To know more, visit-
+// https://pbr-book.org/3ed-2018/Primitives_and_Intersection_Acceleration/Bounding_Volume_Hierarchies
+func NewBVH(shapes ...Shape) *BVH {
+	if len(shapes) == 0 {
+		panic("shapes: BVH of no shapes")
+	}
+
+	items := make([]bvhItem, len(shapes))
+	for i, shape := range shapes {
+		box := shape.BoundingBox()
+		items[i] = bvhItem{shape: shape, box: box, center: box.Center()}
+	}
+
+	return &BVH{shapes: append([]Shape(nil), shapes...), root: newBVHNode(items)}
+}
+
+// Len returns the number of shapes in the BVH.
+func (b *BVH) Len() int {
+	return len(b.shapes)
+}
+
+// Shapes returns a copy of the list of shapes that the BVH was built with.
+func (b *BVH) Shapes() []Shape {
+	return append([]Shape(nil), b.shapes...)
+}
+
+func (b *BVH) Hit(ray *utils.Ray, interval utils.Interval) (*mats.RayHit, bool) {
+	return b.root.Hit(ray, interval)
+}
+
+func (b *BVH) BoundingBox() utils.AABB {
+	return b.root.box
+}
+
+// newBVHNode builds the hierarchy for the given items. It reorders the items.
+func newBVHNode(items []bvhItem) *bvhNode {
+	box := utils.EmptyAABB()
+	for _, item := range items {
+		box = box.Union(item.box)
+	}
+
+	switch len(items) {
+	case 1:
+		return &bvhNode{left: items[0].shape, box: box}
+	case 2:
+		return &bvhNode{left: items[0].shape, right: items[1].shape, box: box}
+	}
+
+	split := sahSplit(items)
+	return &bvhNode{left: newBVHNode(items[:split]), right: newBVHNode(items[split:]), box: box}
+}
+
+// sahSplit sorts the items along the best axis and returns the index at which they should be split,
+// as per the surface area heuristic. Both halves are non-empty.
+func sahSplit(items []bvhItem) int {
+	bestAxis, bestSplit, bestCost := 0, len(items)/2, -1.0
+
+	// The area of the boxes of the items to the right of every index, which is computed backward.
+	rightAreas := make([]float64, len(items))
+
+	for axis := 0; axis < 3; axis++ {
+		sortItems(items, axis)
+
+		rightBox := utils.EmptyAABB()
+		for i := len(items) - 1; i > 0; i-- {
+			rightBox = rightBox.Union(items[i].box)
+			rightAreas[i] = rightBox.SurfaceArea()
+		}
+
+		// The cost of a split is the number of shapes in each half, weighted by the chance of hitting its box.
+		leftBox := utils.EmptyAABB()
+		for i := 1; i < len(items); i++ {
+			leftBox = leftBox.Union(items[i-1].box)
+			cost := leftBox.SurfaceArea()*float64(i) + rightAreas[i]*float64(len(items)-i)
+			if bestCost < 0 || cost < bestCost {
+				bestAxis, bestSplit, bestCost = axis, i, cost
+			}
+		}
+	}
+
+	sortItems(items, bestAxis)
+	return bestSplit
+}
+
+// sortItems sorts the items by the centers of their boxes along the given axis.
+// The sort is stable, so that the hierarchy does not depend on the sorting algorithm.
+func sortItems(items []bvhItem, axis int) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return vecAxis(items[i].center, axis) < vecAxis(items[j].center, axis)
+	})
+}
+
+// vecAxis returns the component of the given vector along the given axis, where 0, 1 and 2 are X, Y and Z.
+func vecAxis(vec *utils.Vec3, axis int) float64 {
+	switch axis {
+	case 1:
+		return vec.Y
+	case 2:
+		return vec.Z
+	default:
+		return vec.X
+	}
+}
+
+func (n *bvhNode) Hit(ray *utils.Ray, interval utils.Interval) (*mats.RayHit, bool) {
+	if !n.box.Hit(ray, interval) {
+		return nil, false
+	}
+
+	// Like a Group, the right child only needs to be closer than the hit on the left.
+	info, isHit := n.left.Hit(ray, interval)
+	if isHit {
+		interval = interval.WithMax(info.Distance)
+	}
+
+	if n.right != nil {
+		if rightInfo, isRightHit := n.right.Hit(ray, interval); isRightHit {
+			return rightInfo, true
+		}
+	}
+
+	return info, isHit
+}
+
+func (n *bvhNode) BoundingBox() utils.AABB {
+	return n.box
+}
@@ -0,0 +1,102 @@
+package shapes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// randomShapes returns the given number of randomly placed spheres and quads, with distinct IDs.
+func randomShapes(count int, rng *random.Generator) []Shape {
+	list := make([]Shape, count)
+	for i := range list {
+		center := rng.Vec3Between(-10, 10)
+		if i%4 == 0 {
+			list[i] = &Quad{Q: center, U: rng.Vec3Between(-1, 1), V: rng.Vec3Between(-1, 1), ID: i}
+			continue
+		}
+		list[i] = &Sphere{Center: center, Radius: rng.FloatBetween(0.1, 1), ID: i}
+	}
+	return list
+}
+
+func TestBVH_Hit(t *testing.T) {
+	rng := random.New(3)
+	list := randomShapes(200, rng)
+	bvh, group := NewBVH(list...), NewGroup(list...)
+
+	if bvh.Len() != len(list) {
+		t.Fatalf("expected %d shapes, got %d", len(list), bvh.Len())
+	}
+	if got, want := bvh.BoundingBox(), group.BoundingBox(); got != want {
+		t.Fatalf("expected the bounding box %v, got %v", want, got)
+	}
+
+	// The BVH must return exactly the hits of the group, which tests every shape.
+	hits := 0
+	for i := 0; i < 5000; i++ {
+		ray := utils.NewRay(rng.Vec3Between(-15, 15), rng.UnitVec3())
+		interval := utils.NewInterval(0.001, math.MaxFloat64)
+
+		want, wantHit := group.Hit(ray, interval)
+		got, gotHit := bvh.Hit(ray, interval)
+		if gotHit != wantHit {
+			t.Fatalf("ray %d: expected hit %v, got %v", i, wantHit, gotHit)
+		}
+		if !wantHit {
+			continue
+		}
+		hits++
+
+		if got.ID != want.ID || got.Distance != want.Distance {
+			t.Fatalf("ray %d: expected shape %d at %v, got shape %d at %v",
+				i, want.ID, want.Distance, got.ID, got.Distance)
+		}
+	}
+
+	if hits == 0 {
+		t.Fatalf("expected some rays to hit the shapes")
+	}
+}
+
+func TestBVH_Single(t *testing.T) {
+	sphere := NewSphere(utils.NewVec3(0, 0, -2), 1, mats.NewMatte(utils.NewColour(0.5, 0.5, 0.5)))
+	bvh := NewBVH(sphere)
+
+	ray := utils.NewRay(utils.NewVec3(0, 0, 0), utils.NewVec3(0, 0, -1))
+	if hit, isHit := bvh.Hit(ray, utils.NewInterval(0, math.MaxFloat64)); !isHit || hit.Distance != 1 {
+		t.Fatalf("expected a hit at distance 1, got %v", hit)
+	}
+}
+
+func BenchmarkBVH_Hit(b *testing.B) {
+	rng := random.New(3)
+	list := randomShapes(1000, rng)
+
+	rays := make([]*utils.Ray, 1024)
+	for i := range rays {
+		rays[i] = utils.NewRay(rng.Vec3Between(-15, 15), rng.UnitVec3())
+	}
+	interval := utils.NewInterval(0.001, math.MaxFloat64)
+
+	benchmarks := []struct {
+		name  string
+		shape Shape
+	}{
+		{name: "bvh", shape: NewBVH(list...)},
+		{name: "group", shape: NewGroup(list...)},
+	}
+
+	for _, bench := range benchmarks {
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				bench.shape.Hit(rays[i%len(rays)], interval)
+			}
+			b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "rays/s")
+		})
+	}
+}
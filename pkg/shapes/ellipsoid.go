@@ -0,0 +1,76 @@
+package shapes
+
+import (
+	"math"
+
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// unitSphere is the sphere that every ellipsoid is a scaled version of.
+var unitSphere = NewSphere(utils.NewVec3(0, 0, 0), 1, nil)
+
+// Ellipsoid represents an axis-aligned ellipsoid. It implements the Shape interface.
+//
+// It is a unit sphere, scaled non-uniformly by its radii and moved to its center.
+// Rays are transformed into the space of the unit sphere for intersection.
+type Ellipsoid struct {
+	// Center is the position vector for the center of the ellipsoid.
+	Center *utils.Vec3
+	// Radii are the semi-axis lengths of the ellipsoid along the x, y and z axes.
+	Radii *utils.Vec3
+
+	// Mat is the material of the ellipsoid.
+	Mat mats.Material
+	// ID is an optional identifier of the ellipsoid, used for the object-ID pass.
+	ID int
+}
+
+// NewEllipsoid returns a new ellipsoid.
+func NewEllipsoid(center, radii *utils.Vec3, mat mats.Material) *Ellipsoid {
+	return &Ellipsoid{Center: center, Radii: radii, Mat: mat}
+}
+
+func (e *Ellipsoid) Hit(ray *utils.Ray, interval utils.Interval) (*mats.RayHit, bool) {
+	// Transform the ray into the space of the unit sphere. The direction is deliberately left
+	// unnormalized, so that distances along the transformed ray are the same as the original one.
+	localRay := &utils.Ray{
+		Origin: e.toLocal(ray.Origin.Sub(e.Center)),
+		Dir:    e.toLocal(ray.Dir),
+		Time:   ray.Time,
+	}
+
+	localHit, isHit := unitSphere.Hit(localRay, interval)
+	if !isHit {
+		return nil, false
+	}
+
+	// The point on the unit sphere is also its outward normal there. Normals are transformed by
+	// the inverse-transpose of the scale, which keeps them perpendicular to the scaled surface.
+	rayHit := &mats.RayHit{
+		Point:    ray.At(localHit.Distance),
+		Distance: localHit.Distance,
+		Normal:   e.toLocal(localHit.Point).Dir(),
+		Mat:      e.Mat,
+		ID:       e.ID,
+	}
+
+	rayHit.IsRayOutside = ray.Dir.Dot(rayHit.Normal) < 0
+	if !rayHit.IsRayOutside {
+		rayHit.Normal = rayHit.Normal.Mul(-1)
+	}
+
+	return rayHit, true
+}
+
+func (e *Ellipsoid) BoundingBox() utils.AABB {
+	extent := utils.NewVec3(math.Abs(e.Radii.X), math.Abs(e.Radii.Y), math.Abs(e.Radii.Z))
+	return utils.NewAABB(e.Center.Sub(extent), e.Center.Add(extent))
+}
+
+// toLocal divides every component of the given vector by the corresponding radius.
+//
+// It is the inverse of the ellipsoid's scale, and also the inverse-transpose since the scale is diagonal.
+func (e *Ellipsoid) toLocal(vec *utils.Vec3) *utils.Vec3 {
+	return utils.NewVec3(vec.X/e.Radii.X, vec.Y/e.Radii.Y, vec.Z/e.Radii.Z)
+}
@@ -0,0 +1,49 @@
+package shapes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestEllipsoid_Hit(t *testing.T) {
+	center := utils.NewVec3(1, 2, 3)
+	ellipsoid := NewEllipsoid(center, utils.NewVec3(2, 1, 1), nil)
+	interval := utils.NewInterval(0, math.MaxFloat64)
+
+	// Rays along the axes, toward the center from both sides, hit at the extents of 2:1:1.
+	for _, extent := range []*utils.Vec3{
+		utils.NewVec3(2, 0, 0), utils.NewVec3(-2, 0, 0),
+		utils.NewVec3(0, 1, 0), utils.NewVec3(0, -1, 0),
+		utils.NewVec3(0, 0, 1), utils.NewVec3(0, 0, -1),
+	} {
+		ray := utils.NewRay(center.Add(extent.Mul(5)), extent.Mul(-1))
+		hit, isHit := ellipsoid.Hit(ray, interval)
+		if !isHit {
+			t.Fatalf("expected the ray along %v to hit", extent)
+		}
+
+		// The normals at the tips point along the axes.
+		if !hit.Point.ApproxEqual(center.Add(extent), 1e-9) || !hit.Normal.ApproxEqual(extent.Dir(), 1e-9) {
+			t.Errorf("expected a hit at %v with the normal %v, got %v with %v",
+				center.Add(extent), extent.Dir(), hit.Point, hit.Normal)
+		}
+	}
+
+	// Elsewhere, the normal is perpendicular to the stretched surface, unlike that of a sphere.
+	point := center.Add(utils.NewVec3(math.Sqrt2, math.Sqrt2/2, 0))
+	normal := utils.NewVec3(1, 2, 0).Dir()
+	hit, isHit := ellipsoid.Hit(utils.NewRay(point.Add(normal.Mul(5)), normal.Mul(-1)), interval)
+	if !isHit || !hit.Point.ApproxEqual(point, 1e-9) || !hit.Normal.ApproxEqual(normal, 1e-9) {
+		t.Errorf("expected a hit at %v with the normal %v, got %v", point, normal, hit)
+	}
+
+	// Just beyond the extents, the rays miss.
+	for _, origin := range []*utils.Vec3{utils.NewVec3(0, 1.01, 5), utils.NewVec3(2.01, 0, 5)} {
+		ray := utils.NewRay(center.Add(origin), utils.NewVec3(0, 0, -1))
+		if _, isHit := ellipsoid.Hit(ray, interval); isHit {
+			t.Errorf("expected the ray from %v to miss", origin)
+		}
+	}
+}
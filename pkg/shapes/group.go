@@ -1,6 +1,8 @@
 package shapes
 
 import (
+	"sync"
+
 	"github.com/shivanshkc/lightshow/pkg/mats"
 	"github.com/shivanshkc/lightshow/pkg/utils"
 )
@@ -10,8 +12,15 @@ import (
 //
 // Its implementation of the Shape interface returns the closest point-of-hit out of
 // all the shapes for the given ray.
+//
+// A group must not contain itself, directly or through nested groups. The Add method panics
+// if it would create such a cycle, but the Shapes field is not checked.
 type Group struct {
 	Shapes []Shape
+
+	// mutex makes the Add, Len, Find and Remove methods safe for concurrent use during scene setup.
+	// The Hit method does not acquire it, so the group must not be mutated while rendering.
+	mutex sync.RWMutex
 }
 
 // NewGroup creates a new Group instance.
@@ -19,19 +28,123 @@ func NewGroup(shapes ...Shape) *Group {
 	return &Group{Shapes: shapes}
 }
 
+// Add appends the given shapes to the group. It is safe for concurrent use.
+//
+// It panics if any of the shapes is the group itself or a group that contains it,
+// since hitting or searching such a group would never end.
+func (g *Group) Add(shapes ...Shape) {
+	// The nested groups are checked before locking this one, since they may (wrongly) contain it.
+	for _, shape := range shapes {
+		if group, ok := shape.(*Group); ok && (group == g || group.contains(g)) {
+			panic("shapes: a group cannot contain itself")
+		}
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.Shapes = append(g.Shapes, shapes...)
+}
+
+// Len returns the number of shapes in the group. It is safe for concurrent use.
+func (g *Group) Len() int {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	return len(g.Shapes)
+}
+
+// BoundingBox returns the box that contains all the shapes of the group. It is safe for concurrent use.
+//
+// It panics if the group is empty, since an empty group has no extent.
+func (g *Group) BoundingBox() utils.AABB {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	if len(g.Shapes) == 0 {
+		panic("shapes: bounding box of an empty group")
+	}
+
+	box := utils.EmptyAABB()
+	for _, shape := range g.Shapes {
+		box = box.Union(shape.BoundingBox())
+	}
+	return box
+}
+
+// contains tells whether the given group is among the shapes of this group, including the nested groups.
+func (g *Group) contains(target *Group) bool {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	for _, shape := range g.Shapes {
+		if group, ok := shape.(*Group); ok && (group == target || group.contains(target)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Find returns all shapes with the given name, including the ones in nested groups.
+// It is safe for concurrent use.
+func (g *Group) Find(name string) []Shape {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	var found []Shape
+	for _, shape := range g.Shapes {
+		if hasName(shape, name) {
+			found = append(found, shape)
+		}
+		if group, ok := shape.(*Group); ok {
+			found = append(found, group.Find(name)...)
+		}
+	}
+
+	return found
+}
+
+// Remove removes all shapes with the given name, including the ones in nested groups,
+// and returns the number of removed shapes. It is safe for concurrent use.
+func (g *Group) Remove(name string) int {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	removed := 0
+	kept := g.Shapes[:0]
+	for _, shape := range g.Shapes {
+		if hasName(shape, name) {
+			removed++
+			continue
+		}
+		if group, ok := shape.(*Group); ok {
+			removed += group.Remove(name)
+		}
+		kept = append(kept, shape)
+	}
+
+	// Clear the leftover references, so that the removed shapes can be garbage collected.
+	for i := len(kept); i < len(g.Shapes); i++ {
+		g.Shapes[i] = nil
+	}
+	g.Shapes = kept
+
+	return removed
+}
+
 // Hit returns the closest point-of-hit out of all the shapes for the given ray.
-func (g *Group) Hit(ray *utils.Ray, minD, maxD float64) (*mats.RayHit, bool) {
+func (g *Group) Hit(ray *utils.Ray, interval utils.Interval) (*mats.RayHit, bool) {
 	// hitAnything will be true if at least a single shape is hit.
 	hitAnything := false
 	// This will keep track of the closest point-of-hit so far.
-	closestSoFar := maxD
+	closestSoFar := interval.Max
 	// This will keep the RayHit record for the closest hit.
 	var closestRayHit *mats.RayHit
 
 	// Loop over all shapes to determine the closest hit.
 	for _, shape := range g.Shapes {
 		// Notice the usage of "closestSoFar" here. It leads to lots of calculation savings.
-		info, isHit := shape.Hit(ray, minD, closestSoFar)
+		info, isHit := shape.Hit(ray, interval.WithMax(closestSoFar))
 		if !isHit {
 			continue
 		}
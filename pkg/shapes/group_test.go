@@ -0,0 +1,126 @@
+package shapes
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestGroup_Add_Concurrent(t *testing.T) {
+	const goroutines, shapesPerGoroutine = 16, 100
+
+	group := NewGroup()
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < shapesPerGoroutine; j++ {
+				// Every sphere is at a different distance along the Z axis.
+				z := -float64(i*shapesPerGoroutine+j+1) * 10
+				group.Add(NewSphere(utils.NewVec3(0, 0, z), 1, nil))
+				// Reads must be safe alongside the writes.
+				_ = group.Len()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if got, want := group.Len(), goroutines*shapesPerGoroutine; got != want {
+		t.Fatalf("expected %d shapes, got %d", want, got)
+	}
+
+	// The group must still work as a shape, returning the closest of all added spheres.
+	ray := utils.NewRay(utils.NewVec3(0, 0, 0), utils.NewVec3(0, 0, -1))
+	hit, isHit := group.Hit(ray, utils.NewInterval(0, math.MaxFloat64))
+	if !isHit {
+		t.Fatalf("expected the ray to hit the group")
+	}
+	if !hit.Point.ApproxEqual(utils.NewVec3(0, 0, -9), 1e-9) {
+		t.Fatalf("expected the closest hit at (0, 0, -9), got %v", hit.Point)
+	}
+
+	// The bounding box spans from the nearest to the farthest sphere.
+	want := utils.NewAABB(utils.NewVec3(-1, -1, -goroutines*shapesPerGoroutine*10-1), utils.NewVec3(1, 1, -9))
+	if got := group.BoundingBox(); got != want {
+		t.Fatalf("expected the bounding box %v, got %v", want, got)
+	}
+}
+
+func TestGroup_BoundingBox_Empty(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected the bounding box of an empty group to panic")
+		}
+	}()
+
+	NewGroup().BoundingBox()
+}
+
+func TestGroup_Add_Cycle(t *testing.T) {
+	inner := NewGroup()
+	outer := NewGroup(inner)
+
+	for name, add := range map[string]func(){
+		"itself":       func() { inner.Add(inner) },
+		"its ancestor": func() { inner.Add(outer) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected adding a group that contains the group to panic", name)
+				}
+			}()
+			add()
+		}()
+	}
+
+	// Nothing was added, so the groups can still be searched without deadlocking.
+	if found := outer.Find("missing"); len(found) != 0 || inner.Len() != 0 {
+		t.Errorf("expected the groups to be unchanged")
+	}
+}
+
+func TestGroup_FindRemove(t *testing.T) {
+	red := NewNamed("red", NewSphere(utils.NewVec3(-2, 0, -5), 1, nil))
+	green := NewNamed("green", NewSphere(utils.NewVec3(0, 0, -5), 1, nil))
+	blue := NewNamed("blue", NewSphere(utils.NewVec3(2, 0, -5), 1, nil))
+	// Another red sphere is nested in a subgroup.
+	nestedRed := NewNamed("red", NewSphere(utils.NewVec3(0, 3, -5), 1, nil))
+	group := NewGroup(red, green, NewGroup(blue, nestedRed))
+
+	if found := group.Find("green"); len(found) != 1 || found[0] != green {
+		t.Errorf("expected to find the green sphere, got %v", found)
+	}
+	if found := group.Find("red"); len(found) != 2 || found[0] != red || found[1] != nestedRed {
+		t.Errorf("expected to find both red spheres, got %v", found)
+	}
+	if found := group.Find("yellow"); len(found) != 0 {
+		t.Errorf("expected to find no yellow spheres, got %v", found)
+	}
+
+	if removed := group.Remove("red"); removed != 2 {
+		t.Errorf("expected 2 red spheres to be removed, got %d", removed)
+	}
+	if found := group.Find("red"); len(found) != 0 {
+		t.Errorf("expected no red spheres after the removal, got %v", found)
+	}
+	if len(group.Find("green")) != 1 || len(group.Find("blue")) != 1 {
+		t.Errorf("expected the green and blue spheres to remain")
+	}
+
+	// The removed spheres are no longer hit, while the remaining ones still are.
+	interval := utils.NewInterval(0, math.MaxFloat64)
+	if _, isHit := group.Hit(utils.NewRay(utils.NewVec3(-2, 0, 0), utils.NewVec3(0, 0, -1)), interval); isHit {
+		t.Errorf("expected the removed red sphere not to be hit")
+	}
+	if _, isHit := group.Hit(utils.NewRay(utils.NewVec3(2, 0, 0), utils.NewVec3(0, 0, -1)), interval); !isHit {
+		t.Errorf("expected the blue sphere to be hit")
+	}
+	if removed := group.Remove("red"); removed != 0 {
+		t.Errorf("expected nothing to be removed the second time, got %d", removed)
+	}
+}
@@ -0,0 +1,124 @@
+package shapes
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// ErrInvalidGrid is returned by NewHeightfield if the grid of heights cannot be triangulated.
+var ErrInvalidGrid = errors.New("invalid heightfield grid")
+
+// Heightfield represents a terrain-like surface defined by a grid of heights.
+// It implements the Shape interface by triangulating the grid into a BVH of triangles.
+//
+// It must be created using the NewHeightfield function. Its grid cannot be changed afterward.
+type Heightfield struct {
+	// Mat is the material of the heightfield.
+	Mat mats.Material
+	// ID is an optional identifier of the heightfield, used for the object-ID pass.
+	ID int
+
+	// heights is the grid of heights, indexed as heights[z][x].
+	heights [][]float64
+	// origin is the world-space position of the (0, 0) grid point.
+	origin *utils.Vec3
+	// scale converts grid coordinates to world-space.
+	// Its X and Z components are the cell sizes and its Y component multiplies the heights.
+	scale *utils.Vec3
+
+	// bvh holds the triangles of the grid.
+	bvh *BVH
+}
+
+// NewHeightfield returns a new Heightfield for the given grid of heights, indexed as heights[z][x].
+// The origin is the world-space position of the (0, 0) grid point, and the scale converts grid
+// coordinates to world-space: its X and Z components are the cell sizes and its Y component multiplies the heights.
+//
+// Every grid cell is split into two triangles, so a grid with W columns and D rows
+// produces 2 * (W-1) * (D-1) triangles. It returns an error wrapping ErrInvalidGrid if the grid
+// has fewer than two rows or columns, or if its rows have different lengths.
+func NewHeightfield(heights [][]float64, origin, scale *utils.Vec3, mat mats.Material) (*Heightfield, error) {
+	if len(heights) < 2 {
+		return nil, fmt.Errorf("%w: %d rows, at least 2 are needed", ErrInvalidGrid, len(heights))
+	}
+	for z, row := range heights {
+		if len(row) < 2 {
+			return nil, fmt.Errorf("%w: row %d has %d heights, at least 2 are needed", ErrInvalidGrid, z, len(row))
+		}
+		if len(row) != len(heights[0]) {
+			return nil, fmt.Errorf("%w: row %d has %d heights, while row 0 has %d",
+				ErrInvalidGrid, z, len(row), len(heights[0]))
+		}
+	}
+
+	hf := &Heightfield{heights: heights, origin: origin, scale: scale, Mat: mat}
+
+	// Every grid point is a single vertex, shared by all the triangles around it.
+	width := len(heights[0])
+	vertices := make([]utils.Vec3, 0, len(heights)*width)
+	for z := range heights {
+		for x := range heights[z] {
+			vertices = append(vertices, *hf.vertex(x, z))
+		}
+	}
+
+	indices := make([]int, 0, 6*(len(heights)-1)*(width-1))
+	for z := 0; z < len(heights)-1; z++ {
+		for x := 0; x < width-1; x++ {
+			// The four corners of the cell.
+			i00, i10 := z*width+x, z*width+x+1
+			i01, i11 := (z+1)*width+x, (z+1)*width+x+1
+
+			indices = append(indices, i00, i01, i10, i10, i01, i11)
+		}
+	}
+
+	// The triangles carry no material, which is set on every hit, so that the Mat and ID can be changed.
+	hf.bvh = NewBVH(NewMesh(vertices, indices, nil).Triangles()...)
+	return hf, nil
+}
+
+// PerlinHeights returns a grid of heights for NewHeightfield, with the given number of columns and rows,
+// generated from the fractal Perlin noise with the given number of octaves. The heights are roughly
+// in the [-1, 1] interval, so they are usually scaled by the heightfield's scale.
+//
+// The frequency is the number of noise features per grid cell. Smaller values give smoother terrain.
+func PerlinHeights(width, depth int, perlin *utils.Perlin, frequency float64, octaves int) [][]float64 {
+	heights := make([][]float64, depth)
+	for z := range heights {
+		heights[z] = make([]float64, width)
+		for x := range heights[z] {
+			// The noise is sampled away from its lattice planes, where it would always be zero.
+			point := utils.NewVec3(float64(x)*frequency, 0.5, float64(z)*frequency)
+			heights[z][x] = perlin.Fractal(point, octaves)
+		}
+	}
+
+	return heights
+}
+
+func (h *Heightfield) Hit(ray *utils.Ray, interval utils.Interval) (*mats.RayHit, bool) {
+	rayHit, isHit := h.bvh.Hit(ray, interval)
+	if !isHit {
+		return nil, false
+	}
+
+	rayHit.Mat, rayHit.ID = h.Mat, h.ID
+	return rayHit, true
+}
+
+func (h *Heightfield) BoundingBox() utils.AABB {
+	return h.bvh.BoundingBox()
+}
+
+// vertex returns the world-space position of the given grid point.
+func (h *Heightfield) vertex(x, z int) *utils.Vec3 {
+	return h.origin.Add(utils.NewVec3(
+		float64(x)*h.scale.X,
+		h.heights[z][x]*h.scale.Y,
+		float64(z)*h.scale.Z,
+	))
+}
@@ -0,0 +1,93 @@
+package shapes
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestHeightfield_Flat(t *testing.T) {
+	// A flat 5x4 grid, with cells of 2x3 units, at the height of 1.5.
+	heights := [][]float64{{0, 0, 0, 0, 0}, {0, 0, 0, 0, 0}, {0, 0, 0, 0, 0}, {0, 0, 0, 0, 0}}
+	hf, err := NewHeightfield(heights, utils.NewVec3(-4, 1.5, -6), utils.NewVec3(2, 10, 3), nil)
+	if err != nil {
+		t.Fatalf("failed to create the heightfield: %v", err)
+	}
+
+	rng := random.New(5)
+	down := utils.NewVec3(0, -1, 0)
+	for i := 0; i < 500; i++ {
+		// Points within the extent, which is 8 units along X and 9 units along Z.
+		x, z := rng.FloatBetween(-4, 4), rng.FloatBetween(-6, 3)
+		hit, isHit := hf.Hit(utils.NewRay(utils.NewVec3(x, 10, z), down), utils.NewInterval(0, math.MaxFloat64))
+		if !isHit {
+			t.Fatalf("expected the ray at (%v, %v) to hit the heightfield", x, z)
+		}
+		if math.Abs(hit.Point.Y-1.5) > 1e-9 || !hit.Normal.ApproxEqual(utils.NewVec3(0, 1, 0), 1e-9) {
+			t.Fatalf("expected a hit at the height of 1.5 facing up, got %v with the normal %v", hit.Point, hit.Normal)
+		}
+	}
+
+	// Rays beyond the extent miss.
+	for _, point := range [][2]float64{{-4.1, 0}, {4.1, 0}, {0, -6.1}, {0, 3.1}} {
+		ray := utils.NewRay(utils.NewVec3(point[0], 10, point[1]), down)
+		if _, isHit := hf.Hit(ray, utils.NewInterval(0, math.MaxFloat64)); isHit {
+			t.Errorf("expected the ray at %v to miss the heightfield", point)
+		}
+	}
+
+	want := utils.NewAABB(utils.NewVec3(-4, 1.5, -6), utils.NewVec3(4, 1.5, 3))
+	if got := hf.BoundingBox(); got != want {
+		t.Errorf("expected the bounding box %v, got %v", want, got)
+	}
+}
+
+func TestHeightfield_InvalidGrid(t *testing.T) {
+	tests := []struct {
+		name    string
+		heights [][]float64
+	}{
+		{name: "no rows", heights: nil},
+		{name: "single row", heights: [][]float64{{0, 1, 2}}},
+		{name: "single column", heights: [][]float64{{0}, {1}}},
+		{name: "shorter row", heights: [][]float64{{0, 1, 2}, {0, 1}}},
+		{name: "longer row", heights: [][]float64{{0, 1}, {0, 1, 2}}},
+	}
+
+	for _, test := range tests {
+		hf, err := NewHeightfield(test.heights, utils.NewVec3(0, 0, 0), utils.NewVec3(1, 1, 1), nil)
+		if !errors.Is(err, ErrInvalidGrid) || hf != nil {
+			t.Errorf("%s: expected ErrInvalidGrid and no heightfield, got %v and %v", test.name, err, hf)
+		}
+	}
+}
+
+func TestPerlinHeights(t *testing.T) {
+	heights := PerlinHeights(16, 12, utils.NewPerlin(7), 0.15, 4)
+	if len(heights) != 12 || len(heights[0]) != 16 {
+		t.Fatalf("expected a grid of 12 rows and 16 columns, got %d rows and %d columns", len(heights), len(heights[0]))
+	}
+
+	minHeight, maxHeight := math.Inf(1), math.Inf(-1)
+	for _, row := range heights {
+		for _, height := range row {
+			minHeight, maxHeight = math.Min(minHeight, height), math.Max(maxHeight, height)
+		}
+	}
+	if minHeight < -1 || maxHeight > 1 || maxHeight-minHeight < 0.1 {
+		t.Errorf("expected varied heights within [-1, 1], got [%v, %v]", minHeight, maxHeight)
+	}
+
+	// The terrain can be triangulated, and rays from above hit it.
+	hf, err := NewHeightfield(heights, utils.NewVec3(0, 0, 0), utils.NewVec3(1, 2, 1), nil)
+	if err != nil {
+		t.Fatalf("failed to create the heightfield: %v", err)
+	}
+	ray := utils.NewRay(utils.NewVec3(7.5, 10, 5.5), utils.NewVec3(0, -1, 0))
+	if _, isHit := hf.Hit(ray, utils.NewInterval(0, math.MaxFloat64)); !isHit {
+		t.Errorf("expected the ray to hit the terrain")
+	}
+}
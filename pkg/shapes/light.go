@@ -0,0 +1,34 @@
+package shapes
+
+import (
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// Light represents a shape that can be sampled directly, which is required for techniques
+// like next-event estimation and multiple importance sampling.
+type Light interface {
+	Shape
+
+	// SamplePoint returns a random point on the light that is visible from the given origin,
+	// along with the outward surface normal at that point. The given Generator is the source of randomness.
+	//
+	// The returned pdf is the probability density of choosing that point, measured with
+	// respect to the solid angle as seen from the origin.
+	SamplePoint(origin *utils.Vec3, rng *random.Generator) (point, normal *utils.Vec3, pdf float64)
+
+	// PDFValue returns the probability density (with respect to solid angle) with which
+	// SamplePoint would choose the point that the ray from origin along dir hits first.
+	// It is zero if the ray misses the light.
+	PDFValue(origin, dir *utils.Vec3) float64
+}
+
+// NewAreaLight returns a rectangular light source. It is a quad with a DiffuseLight material,
+// which emits the given colour from its front face (toward which uEdge x vEdge points)
+// and is dark from the back.
+//
+// The returned quad should be added to the world for it to be visible.
+func NewAreaLight(corner, uEdge, vEdge *utils.Vec3, emission *utils.Colour) *Quad {
+	return NewQuad(corner, uEdge, vEdge, mats.NewDiffuseLight(emission))
+}
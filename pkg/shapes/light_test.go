@@ -0,0 +1,42 @@
+package shapes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestNewAreaLight(t *testing.T) {
+	// A light on the ceiling, facing down, since X x Z points down.
+	emission, black := utils.NewColour(4, 3, 2), utils.NewColour(0, 0, 0)
+	var light Light = NewAreaLight(utils.NewVec3(-1, 2, -1), utils.NewVec3(2, 0, 0), utils.NewVec3(0, 0, 2), emission)
+	interval := utils.NewInterval(0, math.MaxFloat64)
+
+	tests := []struct {
+		name string
+		ray  *utils.Ray
+		want *utils.Colour
+	}{
+		{name: "front", ray: utils.NewRay(utils.NewVec3(0.2, 0, 0.3), utils.NewVec3(0, 1, 0)), want: emission},
+		{name: "back", ray: utils.NewRay(utils.NewVec3(0.2, 5, 0.3), utils.NewVec3(0, -1, 0)), want: black},
+	}
+
+	for _, test := range tests {
+		hit, isHit := light.Hit(test.ray, interval)
+		if !isHit {
+			t.Fatalf("%s: expected the ray to hit the light", test.name)
+		}
+
+		got := hit.Mat.(mats.Emitter).Emit(test.ray, hit)
+		if !got.ApproxEqual(test.want, 1e-9) {
+			t.Errorf("%s: expected the emission %v, got %v", test.name, test.want, got)
+		}
+
+		// The light does not reflect anything.
+		if _, _, isScattered := hit.Mat.Scatter(test.ray, hit, nil); isScattered {
+			t.Errorf("%s: expected the light not to scatter the ray", test.name)
+		}
+	}
+}
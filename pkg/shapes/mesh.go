@@ -0,0 +1,155 @@
+package shapes
+
+import (
+	"math"
+
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// Mesh represents a triangle mesh. It implements the Shape interface.
+//
+// The vertices are stored once and shared by all the triangles that use them,
+// which saves a lot of memory for large meshes, where most vertices are shared by several triangles.
+type Mesh struct {
+	// Vertices are the position vectors of all the vertices of the mesh.
+	Vertices []utils.Vec3
+	// Indices holds three indices into the Vertices for every triangle.
+	// The front (outer) face of a triangle is the one from which its vertices appear counter-clockwise.
+	Indices []int
+
+	// Mat is the material of the mesh.
+	Mat mats.Material
+	// ID is an optional identifier of the mesh, used for the object-ID pass.
+	ID int
+}
+
+// NewMesh returns a new mesh.
+func NewMesh(vertices []utils.Vec3, indices []int, mat mats.Material) *Mesh {
+	return &Mesh{Vertices: vertices, Indices: indices, Mat: mat}
+}
+
+// Hit returns the closest point-of-hit out of all the triangles for the given ray.
+func (m *Mesh) Hit(ray *utils.Ray, interval utils.Interval) (*mats.RayHit, bool) {
+	// This will keep the RayHit record for the closest hit.
+	var closestRayHit *mats.RayHit
+
+	for i := 0; i+2 < len(m.Indices); i += 3 {
+		a, b, c := &m.Vertices[m.Indices[i]], &m.Vertices[m.Indices[i+1]], &m.Vertices[m.Indices[i+2]]
+
+		// Like a Group, only the hits closer than the closest one so far are considered.
+		if info, isHit := hitTriangle(ray, a, b, c, interval); isHit {
+			closestRayHit, interval = info, interval.WithMax(info.Distance)
+		}
+	}
+
+	if closestRayHit == nil {
+		return nil, false
+	}
+
+	closestRayHit.Mat, closestRayHit.ID = m.Mat, m.ID
+	return closestRayHit, true
+}
+
+// Triangles returns a view of every triangle of the mesh, as a separate shape, so that they can be put in a BVH.
+// The views refer to the vertices of the mesh instead of copying them, and use its Mat and ID.
+func (m *Mesh) Triangles() []Shape {
+	triangles := make([]Shape, 0, len(m.Indices)/3)
+	for i := 0; i+2 < len(m.Indices); i += 3 {
+		triangles = append(triangles, &meshTriangle{mesh: m, first: i})
+	}
+	return triangles
+}
+
+// BoundingBox returns the box that contains all the triangles of the mesh.
+func (m *Mesh) BoundingBox() utils.AABB {
+	box := utils.EmptyAABB()
+	for _, index := range m.Indices {
+		box = box.AddPoint(&m.Vertices[index])
+	}
+	return box
+}
+
+// meshTriangle is a view of a triangle of a Mesh. It implements the Shape interface.
+type meshTriangle struct {
+	mesh *Mesh
+	// first is the index, in the Indices of the mesh, of the first vertex of the triangle.
+	first int
+}
+
+func (t *meshTriangle) Hit(ray *utils.Ray, interval utils.Interval) (*mats.RayHit, bool) {
+	a, b, c := t.vertices()
+	rayHit, isHit := hitTriangle(ray, a, b, c, interval)
+	if !isHit {
+		return nil, false
+	}
+
+	rayHit.Mat, rayHit.ID = t.mesh.Mat, t.mesh.ID
+	return rayHit, true
+}
+
+func (t *meshTriangle) BoundingBox() utils.AABB {
+	a, b, c := t.vertices()
+	return utils.NewAABB(a, b).AddPoint(c)
+}
+
+// vertices returns the vertices of the triangle.
+func (t *meshTriangle) vertices() (a, b, c *utils.Vec3) {
+	vertices, indices := t.mesh.Vertices, t.mesh.Indices
+	return &vertices[indices[t.first]], &vertices[indices[t.first+1]], &vertices[indices[t.first+2]]
+}
+
+// hitTriangle attempts to hit the triangle with the given vertices with the given ray.
+// Its hits do not have a material, which is set by the caller.
+func hitTriangle(ray *utils.Ray, a, b, c *utils.Vec3, interval utils.Interval) (*mats.RayHit, bool) {
+	// This is the Möller–Trumbore intersection algorithm. To understand the math, visit-
+	// https://en.wikipedia.org/wiki/M%C3%B6ller%E2%80%93Trumbore_intersection_algorithm
+	//
+	// Allocation-free arithmetic is used until a hit is confirmed, since most rays miss.
+	var edge1, edge2, pVec, tVec, qVec utils.Vec3
+	b.SubInto(a, &edge1)
+	c.SubInto(a, &edge2)
+
+	ray.Dir.CrossInto(&edge2, &pVec)
+	determinant := edge1.Dot(&pVec)
+	// The ray is parallel to the triangle.
+	if math.Abs(determinant) < 1e-12 {
+		return nil, false
+	}
+
+	invDet := 1 / determinant
+
+	// First barycentric coordinate.
+	ray.Origin.SubInto(a, &tVec)
+	u := tVec.Dot(&pVec) * invDet
+	if u < 0 || u > 1 {
+		return nil, false
+	}
+
+	// Second barycentric coordinate.
+	tVec.CrossInto(&edge1, &qVec)
+	v := ray.Dir.Dot(&qVec) * invDet
+	if v < 0 || u+v > 1 {
+		return nil, false
+	}
+
+	distance := edge2.Dot(&qVec) * invDet
+	if !interval.Surrounds(distance) {
+		return nil, false
+	}
+
+	// Create the RayHit record.
+	rayHit := &mats.RayHit{
+		Point:    ray.At(distance),
+		Distance: distance,
+		Normal:   edge1.Cross(&edge2).Dir(),
+	}
+
+	// Flip the normal if it is on the same side as the ray.
+	rayHit.IsRayOutside = ray.Dir.Dot(rayHit.Normal) < 0
+	if !rayHit.IsRayOutside {
+		rayHit.Normal = rayHit.Normal.Mul(-1)
+	}
+
+	return rayHit, true
+}
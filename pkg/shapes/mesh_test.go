@@ -0,0 +1,84 @@
+package shapes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// gridMesh returns a bumpy grid of the given number of cells along X and Z, with two triangles per cell,
+// where every vertex is shared by up to six triangles.
+func gridMesh(cells int) *Mesh {
+	var vertices []utils.Vec3
+	for z := 0; z <= cells; z++ {
+		for x := 0; x <= cells; x++ {
+			vertices = append(vertices, *utils.NewVec3(float64(x), math.Sin(float64(x*z)), -float64(z)))
+		}
+	}
+
+	var indices []int
+	for z := 0; z < cells; z++ {
+		for x := 0; x < cells; x++ {
+			i00, i10 := z*(cells+1)+x, z*(cells+1)+x+1
+			i01, i11 := (z+1)*(cells+1)+x, (z+1)*(cells+1)+x+1
+			indices = append(indices, i00, i10, i01, i10, i11, i01)
+		}
+	}
+
+	return NewMesh(vertices, indices, nil)
+}
+
+func TestMesh_Hit(t *testing.T) {
+	const cells = 10
+	mesh := gridMesh(cells)
+
+	// The same triangles, each with its own copies of the vertices.
+	independent := NewGroup()
+	for i := 0; i < len(mesh.Indices); i += 3 {
+		vertices := []utils.Vec3{
+			mesh.Vertices[mesh.Indices[i]], mesh.Vertices[mesh.Indices[i+1]], mesh.Vertices[mesh.Indices[i+2]],
+		}
+		independent.Add(NewMesh(vertices, []int{0, 1, 2}, nil))
+	}
+	views := NewBVH(mesh.Triangles()...)
+
+	rng := random.New(4)
+	interval := utils.NewInterval(0, math.MaxFloat64)
+	hits := 0
+	for i := 0; i < 2000; i++ {
+		origin := utils.NewVec3(rng.FloatBetween(-2, cells+2), 5, rng.FloatBetween(-cells-2, 2))
+		ray := utils.NewRay(origin, utils.NewVec3(rng.FloatBetween(-1, 1), -1, rng.FloatBetween(-1, 1)))
+
+		want, wantHit := independent.Hit(ray, interval)
+		for name, shape := range map[string]Shape{"mesh": mesh, "views": views} {
+			got, isHit := shape.Hit(ray, interval)
+			if isHit != wantHit {
+				t.Fatalf("%s: expected the hit to be %v for the ray %v, got %v", name, wantHit, ray, isHit)
+			}
+			if isHit && (got.Distance != want.Distance || *got.Normal != *want.Normal) {
+				t.Fatalf("%s: expected the hit %v for the ray %v, got %v", name, want, ray, got)
+			}
+		}
+		if wantHit {
+			hits++
+		}
+	}
+	if hits < 500 {
+		t.Errorf("expected many rays to hit the mesh, got %d hits", hits)
+	}
+
+	// The shared vertices are stored once, instead of once per triangle that uses them.
+	triangles := len(mesh.Indices) / 3
+	if len(mesh.Vertices) != (cells+1)*(cells+1) || 4*len(mesh.Vertices) > 3*triangles {
+		t.Errorf("expected %d shared vertices, far fewer than the %d of the independent triangles, got %d",
+			(cells+1)*(cells+1), 3*triangles, len(mesh.Vertices))
+	}
+
+	// Creating the views of the triangles allocates no vertices, only the views themselves and their slice.
+	allocs := testing.AllocsPerRun(10, func() { mesh.Triangles() })
+	if allocs > float64(triangles+1) {
+		t.Errorf("expected at most %d allocations for the views, got %v", triangles+1, allocs)
+	}
+}
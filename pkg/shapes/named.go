@@ -0,0 +1,30 @@
+package shapes
+
+// Named is implemented by the shapes that have a name, which can be used to find or remove them in a Group.
+type Named interface {
+	// Name returns the name of the shape.
+	Name() string
+}
+
+// NamedShape attaches a name to a shape. It implements the Shape and Named interfaces.
+type NamedShape struct {
+	// Shape is the named shape. Its Hit method is used as is.
+	Shape
+	// name of the shape. It does not need to be unique.
+	name string
+}
+
+// NewNamed returns the given shape with the given name attached.
+func NewNamed(name string, shape Shape) *NamedShape {
+	return &NamedShape{Shape: shape, name: name}
+}
+
+func (n *NamedShape) Name() string {
+	return n.name
+}
+
+// hasName tells whether the given shape is named with the given name.
+func hasName(shape Shape, name string) bool {
+	named, ok := shape.(Named)
+	return ok && named.Name() == name
+}
@@ -0,0 +1,58 @@
+package shapes
+
+import (
+	"math"
+
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// PulsingSphere is a sphere whose radius changes with the time of the ray. It implements the Shape interface.
+//
+// The radius goes linearly from Radius0 at Time0 to Radius1 at Time1, and stays at those values
+// before Time0 and after Time1. Use the camera's shutter times to render it with motion blur.
+type PulsingSphere struct {
+	// Center is the position vector for the center of the sphere.
+	Center *utils.Vec3
+	// Radius0 and Radius1 are the radii of the sphere at Time0 and Time1 respectively.
+	Radius0, Radius1 float64
+	// Time0 and Time1 are the times between which the radius changes.
+	Time0, Time1 float64
+
+	// Bias works the same way as the Bias of the Sphere.
+	Bias float64
+
+	// Mat is the material of the sphere.
+	Mat mats.Material
+	// ID is an optional identifier of the sphere, used for the object-ID pass.
+	ID int
+}
+
+// NewPulsingSphere returns a new pulsing sphere that goes from radius0 at time0 to radius1 at time1.
+func NewPulsingSphere(center *utils.Vec3, radius0, radius1, time0, time1 float64, mat mats.Material) *PulsingSphere {
+	return &PulsingSphere{Center: center, Radius0: radius0, Radius1: radius1, Time0: time0, Time1: time1, Mat: mat}
+}
+
+func (p *PulsingSphere) Hit(ray *utils.Ray, interval utils.Interval) (*mats.RayHit, bool) {
+	sphere := Sphere{Center: p.Center, Radius: p.RadiusAt(ray.Time), Bias: p.Bias, Mat: p.Mat, ID: p.ID}
+	return sphere.Hit(ray, interval)
+}
+
+// BoundingBox returns the box that contains the sphere at all times.
+func (p *PulsingSphere) BoundingBox() utils.AABB {
+	sphere := Sphere{Center: p.Center, Radius: math.Max(math.Abs(p.Radius0), math.Abs(p.Radius1))}
+	return sphere.BoundingBox()
+}
+
+// RadiusAt returns the radius of the sphere at the given time.
+func (p *PulsingSphere) RadiusAt(time float64) float64 {
+	if p.Time1 <= p.Time0 || time <= p.Time0 {
+		return p.Radius0
+	}
+	if time >= p.Time1 {
+		return p.Radius1
+	}
+
+	fraction := (time - p.Time0) / (p.Time1 - p.Time0)
+	return p.Radius0 + (p.Radius1-p.Radius0)*fraction
+}
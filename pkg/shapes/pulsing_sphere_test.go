@@ -0,0 +1,44 @@
+package shapes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestPulsingSphere_Hit(t *testing.T) {
+	sphere := NewPulsingSphere(utils.NewVec3(0, 0, -5), 1, 2, 0.25, 0.75, nil)
+	interval := utils.NewInterval(0, math.MaxFloat64)
+
+	tests := []struct {
+		time   float64
+		radius float64
+	}{
+		{time: 0, radius: 1},
+		{time: 0.25, radius: 1},
+		{time: 0.5, radius: 1.5},
+		{time: 0.75, radius: 2},
+		{time: 1, radius: 2},
+	}
+
+	for _, test := range tests {
+		ray := utils.NewRay(utils.NewVec3(0, 0, 0), utils.NewVec3(0, 0, -1))
+		ray.Time = test.time
+
+		hit, isHit := sphere.Hit(ray, interval)
+		if !isHit || math.Abs(hit.Distance-(5-test.radius)) > 1e-9 {
+			t.Errorf("expected the ray at the time %v to hit the radius %v, %v units away, got %v",
+				test.time, test.radius, 5-test.radius, hit)
+		}
+	}
+
+	// A ray that passes 1.5 units from the center only hits the larger sphere.
+	for time, want := range map[float64]bool{0.25: false, 0.75: true} {
+		ray := utils.NewRay(utils.NewVec3(1.5, 0, 0), utils.NewVec3(0, 0, -1))
+		ray.Time = time
+		if _, isHit := sphere.Hit(ray, interval); isHit != want {
+			t.Errorf("expected the hit to be %v at the time %v, got %v", want, time, isHit)
+		}
+	}
+}
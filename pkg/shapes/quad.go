@@ -0,0 +1,127 @@
+package shapes
+
+import (
+	"math"
+
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// Quad represents a parallelogram. It implements the Shape and Light interfaces.
+// Its hits have UV coordinates along the U and V edges.
+//
+// Its front (outer) face is the one toward which U x V points.
+type Quad struct {
+	// Q is the position vector of a corner of the quad.
+	Q *utils.Vec3
+	// U and V are the two edges of the quad that start at Q.
+	U, V *utils.Vec3
+
+	// Mat is the material of the quad.
+	Mat mats.Material
+	// ID is an optional identifier of the quad, used for the object-ID pass.
+	ID int
+}
+
+// NewQuad returns a new quad.
+func NewQuad(q, u, v *utils.Vec3, mat mats.Material) *Quad {
+	return &Quad{Q: q, U: u, V: v, Mat: mat}
+}
+
+func (q *Quad) Hit(ray *utils.Ray, interval utils.Interval) (*mats.RayHit, bool) {
+	// To understand the math, visit-
+	// https://raytracing.github.io/books/RayTracingTheNextWeek.html#quadrilaterals
+	normalUnscaled := q.U.Cross(q.V)
+	normal := normalUnscaled.Dir()
+
+	// The ray is parallel to the plane of the quad.
+	denominator := normal.Dot(ray.Dir)
+	if math.Abs(denominator) < 1e-8 {
+		return nil, false
+	}
+
+	distance := (normal.Dot(q.Q) - normal.Dot(ray.Origin)) / denominator
+	if !interval.Surrounds(distance) {
+		return nil, false
+	}
+
+	// Planar coordinates of the point-of-hit, with respect to the edges.
+	point := ray.At(distance)
+	alpha, beta := q.planarCoordinates(point, normalUnscaled)
+	if alpha < 0 || alpha > 1 || beta < 0 || beta > 1 {
+		return nil, false
+	}
+
+	// The planar coordinates also serve as the UV coordinates, with Q at (0, 0) and Q + U + V at (1, 1).
+	rayHit := &mats.RayHit{
+		Point:    point,
+		Distance: distance,
+		Normal:   normal,
+		U:        alpha,
+		V:        beta,
+		Tangent:  *q.U.Dir(),
+		Mat:      q.Mat,
+		ID:       q.ID,
+	}
+
+	// Flip the normal if it is on the same side as the ray.
+	rayHit.IsRayOutside = denominator < 0
+	if !rayHit.IsRayOutside {
+		rayHit.Normal = rayHit.Normal.Mul(-1)
+	}
+
+	return rayHit, true
+}
+
+func (q *Quad) BoundingBox() utils.AABB {
+	return parallelogramBox(q.Q, q.U, q.V)
+}
+
+// SamplePoint returns a uniformly distributed random point on the quad.
+func (q *Quad) SamplePoint(origin *utils.Vec3, rng *random.Generator) (*utils.Vec3, *utils.Vec3, float64) {
+	point := q.Q.Add(q.U.Mul(rng.Float())).Add(q.V.Mul(rng.Float()))
+	normal := q.U.Cross(q.V).Dir()
+
+	return point, normal, q.solidAnglePDF(origin, point, normal)
+}
+
+// PDFValue returns the solid-angle probability density of SamplePoint choosing the point
+// that the given ray hits.
+func (q *Quad) PDFValue(origin, dir *utils.Vec3) float64 {
+	hit, isHit := q.Hit(utils.NewRay(origin, dir), utils.NewInterval(0, math.MaxFloat64))
+	if !isHit {
+		return 0
+	}
+
+	return q.solidAnglePDF(origin, hit.Point, hit.Normal)
+}
+
+// parallelogramBox returns the bounding box of the parallelogram with the corner q and the edges u and v.
+func parallelogramBox(q, u, v *utils.Vec3) utils.AABB {
+	return utils.NewAABB(q, q.Add(u).Add(v)).Union(utils.NewAABB(q.Add(u), q.Add(v)))
+}
+
+// planarCoordinates returns the coordinates of the given point (on the plane of the quad)
+// along the U and V edges. Points inside the quad have both coordinates in the [0, 1] interval.
+func (q *Quad) planarCoordinates(point, normalUnscaled *utils.Vec3) (float64, float64) {
+	w := normalUnscaled.Div(normalUnscaled.DotSelf())
+	p := point.Sub(q.Q)
+
+	return w.Dot(p.Cross(q.V)), w.Dot(q.U.Cross(p))
+}
+
+// solidAnglePDF converts the uniform area probability density of the quad
+// to a solid-angle probability density as seen from the origin.
+func (q *Quad) solidAnglePDF(origin, point, normal *utils.Vec3) float64 {
+	area := q.U.Cross(q.V).Mag()
+
+	toOrigin := origin.Sub(point)
+	distanceSq := toOrigin.DotSelf()
+	cosLight := math.Abs(normal.Dot(toOrigin.Dir()))
+	if cosLight < 1e-8 {
+		return 0
+	}
+
+	return distanceSq / (cosLight * area)
+}
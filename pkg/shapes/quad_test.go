@@ -0,0 +1,56 @@
+package shapes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestQuad_Hit(t *testing.T) {
+	// A slanted parallelogram, whose edges are not perpendicular.
+	quad := NewQuad(utils.NewVec3(1, 0, -2), utils.NewVec3(2, 1, 0), utils.NewVec3(0.5, 1, -1), nil)
+	normal := quad.U.Cross(quad.V).Dir()
+	interval := utils.NewInterval(0, math.MaxFloat64)
+
+	// hitAt casts a ray along the normal, toward the point at the given planar coordinates.
+	hitAt := func(alpha, beta float64) (*utils.Vec3, *utils.Ray) {
+		point := quad.Q.Add(quad.U.Mul(alpha)).Add(quad.V.Mul(beta))
+		return point, utils.NewRay(point.Add(normal.Mul(3)), normal.Mul(-1))
+	}
+
+	// The center and (just inside) the corners are hit, with UV coordinates along the edges.
+	const low, high = 1e-6, 1 - 1e-6
+	for _, uv := range [][2]float64{{0.5, 0.5}, {low, low}, {high, low}, {low, high}, {high, high}} {
+		point, ray := hitAt(uv[0], uv[1])
+		hit, isHit := quad.Hit(ray, interval)
+		if !isHit {
+			t.Fatalf("expected the ray toward the UV %v to hit", uv)
+		}
+		if !hit.Point.ApproxEqual(point, 1e-9) || math.Abs(hit.Distance-3) > 1e-9 {
+			t.Errorf("expected a hit at %v, 3 units away, got %v, %v units away", point, hit.Point, hit.Distance)
+		}
+		if math.Abs(hit.U-uv[0]) > 1e-9 || math.Abs(hit.V-uv[1]) > 1e-9 {
+			t.Errorf("expected the UV %v, got (%v, %v)", uv, hit.U, hit.V)
+		}
+		if hit.U < 0 || hit.U > 1 || hit.V < 0 || hit.V > 1 {
+			t.Errorf("expected the UV within [0, 1], got (%v, %v)", hit.U, hit.V)
+		}
+		if !hit.Normal.ApproxEqual(normal, 1e-9) || !hit.IsRayOutside {
+			t.Errorf("expected the front face with the normal %v, got %v", normal, hit.Normal)
+		}
+	}
+
+	// The rays just past every edge miss.
+	for _, uv := range [][2]float64{{-1e-3, 0.5}, {1 + 1e-3, 0.5}, {0.5, -1e-3}, {0.5, 1 + 1e-3}} {
+		_, ray := hitAt(uv[0], uv[1])
+		if _, isHit := quad.Hit(ray, interval); isHit {
+			t.Errorf("expected the ray toward the UV %v to miss", uv)
+		}
+	}
+
+	// The rays parallel to the quad miss.
+	if _, isHit := quad.Hit(utils.NewRay(utils.NewVec3(0, 0, 0), quad.U), interval); isHit {
+		t.Errorf("expected the ray parallel to the quad to miss")
+	}
+}
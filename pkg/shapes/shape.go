@@ -10,16 +10,19 @@ type Shape interface {
 	// Hit attempts to hit the shape with the given ray. In other words, it
 	// checks if the surface of the shape intersects with the trajectory of the ray.
 	//
-	// The shape is considered hit (or intersected) if the point-of-hit lies
-	// within the given distance range (see the "minD" and "maxD" arguments).
+	// The shape is considered hit (or intersected) if the distance of the point-of-hit
+	// from the ray origin lies within the given interval, excluding its ends.
 	//
 	// If the shape is hit, the "isHit" flag is true and "info" contains the hit info.
 	// Otherwise, "isHit" is false and "info" is nil.
 	//
-	// If the point of hit is closer (to the ray origin) than the minimum distance
-	// value or farther than the maximum distance value, the shape will not
-	// be visible.
+	// If the point of hit is closer (to the ray origin) than the interval's Min
+	// or farther than its Max, the shape will not be visible.
 	//
-	// In most cases, the minD argument will be zero.
-	Hit(ray *utils.Ray, minD, maxD float64) (info *mats.RayHit, isHit bool)
+	// In most cases, the interval's Min will be zero.
+	Hit(ray *utils.Ray, interval utils.Interval) (info *mats.RayHit, isHit bool)
+
+	// BoundingBox returns an axis-aligned box that contains the whole shape.
+	// Acceleration structures like the BVH use it to skip the shapes that a ray cannot hit.
+	BoundingBox() utils.AABB
 }
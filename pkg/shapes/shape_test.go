@@ -0,0 +1,64 @@
+package shapes
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestShapes_BoundingBox(t *testing.T) {
+	heightfield, err := NewHeightfield([][]float64{{0, 1, 0}, {1, 2, 1}, {0, 1, 0}},
+		utils.NewVec3(-1, 0, 2), utils.NewVec3(1, 0.5, 1), nil)
+	if err != nil {
+		t.Fatalf("failed to create the heightfield: %v", err)
+	}
+
+	opaque := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	opaque.SetNRGBA(0, 0, color.NRGBA{R: 255, A: 255})
+
+	cases := map[string]Shape{
+		"sphere":         NewSphere(utils.NewVec3(1, 2, 3), 1.5, nil),
+		"pulsing sphere": NewPulsingSphere(utils.NewVec3(1, 2, 3), 0.5, 1.5, 0, 1, nil),
+		"ellipsoid":      NewEllipsoid(utils.NewVec3(1, 2, 3), utils.NewVec3(2, 1, 0.5), nil),
+		"quad":           NewQuad(utils.NewVec3(0, 1, 2), utils.NewVec3(2, 0.5, 0), utils.NewVec3(0, 1, -2), nil),
+		"billboard":      NewBillboard(utils.NewVec3(0, 1, 2), utils.NewVec3(2, 0, 0), utils.NewVec3(0, 2, 0), opaque),
+		"heightfield":    heightfield,
+	}
+
+	rng := random.New(1)
+	for name, shape := range cases {
+		box := shape.BoundingBox()
+		// A tiny margin allows for the rounding of the hit points.
+		margin := utils.AABB{X: box.X.Expand(1e-9), Y: box.Y.Expand(1e-9), Z: box.Z.Expand(1e-9)}
+
+		hits := 0
+		for i := 0; i < 1000; i++ {
+			// Rays from all around, aimed roughly at the center of the box.
+			origin := box.Center().Add(rng.UnitVec3().Mul(10))
+			target := box.Center().Add(rng.Vec3Between(-1.5, 1.5))
+			ray := utils.NewRay(origin, target.Sub(origin))
+
+			hit, isHit := shape.Hit(ray, utils.NewInterval(0, math.MaxFloat64))
+			if !isHit {
+				continue
+			}
+			hits++
+
+			point := hit.Point
+			if !margin.X.Contains(point.X) || !margin.Y.Contains(point.Y) || !margin.Z.Contains(point.Z) {
+				t.Fatalf("%s: hit at %v outside the bounding box %v", name, point, box)
+			}
+			if !box.Hit(ray, utils.NewInterval(0, math.MaxFloat64)) {
+				t.Fatalf("%s: the ray hits the shape at %v but misses its bounding box", name, point)
+			}
+		}
+
+		if hits == 0 {
+			t.Errorf("%s: expected some rays to hit the shape", name)
+		}
+	}
+}
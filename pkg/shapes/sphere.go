@@ -4,6 +4,7 @@ import (
 	"math"
 
 	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/random"
 	"github.com/shivanshkc/lightshow/pkg/utils"
 )
 
@@ -14,8 +15,15 @@ type Sphere struct {
 	// Radius of the sphere.
 	Radius float64
 
+	// Bias is the minimum distance at which the rays leaving the surface of the sphere register hits.
+	// Very small spheres need a smaller bias and very large ones need a bigger bias to avoid shadow acne.
+	// Zero means the renderer's ShadowEpsilon.
+	Bias float64
+
 	// Mat is the material of the sphere.
 	Mat mats.Material
+	// ID is an optional identifier of the sphere, used for the object-ID pass.
+	ID int
 }
 
 // NewSphere returns a new sphere.
@@ -23,11 +31,13 @@ func NewSphere(center *utils.Vec3, radius float64, mat mats.Material) *Sphere {
 	return &Sphere{Center: center, Radius: radius, Mat: mat}
 }
 
-func (s *Sphere) Hit(ray *utils.Ray, minD, maxD float64) (*mats.RayHit, bool) {
+func (s *Sphere) Hit(ray *utils.Ray, interval utils.Interval) (*mats.RayHit, bool) {
 	// To understand the math, visit-
 	// https://raytracing.github.io/books/RayTracingInOneWeekend.html#addingasphere/ray-sphereintersection
 
-	oc := ray.Origin.Sub(s.Center)
+	// Allocation-free arithmetic is used until a hit is confirmed, since most rays miss.
+	var oc utils.Vec3
+	ray.Origin.SubInto(s.Center, &oc)
 
 	// These are the coefficients of the quadractic equation.
 	// To understand the "bHalf" logic, visit-
@@ -49,10 +59,10 @@ func (s *Sphere) Hit(ray *utils.Ray, minD, maxD float64) (*mats.RayHit, bool) {
 
 	// The smaller root of the equation.
 	closerRoot := (-bHalf - sqrtDiscrim) / a
-	if !isWithin(closerRoot, minD, maxD) {
+	if !interval.Surrounds(closerRoot) {
 		// The bigger root of the equation.
 		closerRoot = (-bHalf + sqrtDiscrim) / a
-		if !isWithin(closerRoot, minD, maxD) {
+		if !interval.Surrounds(closerRoot) {
 			// Both hits are out of visual range.
 			return nil, false
 		}
@@ -60,13 +70,16 @@ func (s *Sphere) Hit(ray *utils.Ray, minD, maxD float64) (*mats.RayHit, bool) {
 
 	// Create the RayHit record.
 	rayHit := &mats.RayHit{
-		Point:    ray.Point(closerRoot),
+		Point:    ray.At(closerRoot),
 		Distance: closerRoot,
+		Bias:     s.Bias,
 		Mat:      s.Mat,
+		ID:       s.ID,
 	}
 
 	// Calculate the normal and whether is it on the same side as the Ray.
 	rayHit.Normal = rayHit.Point.Sub(s.Center).Dir()
+	rayHit.U, rayHit.V, rayHit.Tangent = sphereUV(rayHit.Normal)
 	// To understand this math, visit-
 	//nolint:lll
 	// https://raytracing.github.io/books/RayTracingInOneWeekend.html#surfacenormalsandmultipleobjects/frontfacesversusbackfaces
@@ -78,7 +91,144 @@ func (s *Sphere) Hit(ray *utils.Ray, minD, maxD float64) (*mats.RayHit, bool) {
 	return rayHit, true
 }
 
-// isWithin checks if the given value is within min and max, both exclusive.
-func isWithin(value, min, max float64) bool {
-	return value > min && value < max
+// sphereUV returns the UV coordinates and the tangent of the point on a sphere with the given outward normal.
+// U goes around the Y axis, starting and ending at -X, and V goes from the bottom (-Y) to the top (+Y).
+// To know more, visit-
+// https://raytracing.github.io/books/RayTracingTheNextWeek.html#texturemapping/texturecoordinatesforspheres
+func sphereUV(normal *utils.Vec3) (u, v float64, tangent utils.Vec3) {
+	u = (math.Atan2(-normal.Z, normal.X) + math.Pi) / (2 * math.Pi)
+	v = math.Acos(math.Max(-1, math.Min(-normal.Y, 1))) / math.Pi
+
+	// U grows along the circle of latitude, which shrinks to a point at the poles.
+	if math.Abs(normal.X)+math.Abs(normal.Z) > 1e-12 {
+		tangent = *utils.NewVec3(normal.Z, 0, -normal.X).Dir()
+	}
+	return u, v, tangent
+}
+
+func (s *Sphere) BoundingBox() utils.AABB {
+	radius := math.Abs(s.Radius)
+	extent := utils.NewVec3(radius, radius, radius)
+	return utils.NewAABB(s.Center.Sub(extent), s.Center.Add(extent))
+}
+
+// SamplePoint returns a uniformly distributed random point on the cap of the sphere
+// that is visible from the given origin.
+func (s *Sphere) SamplePoint(origin *utils.Vec3, rng *random.Generator) (*utils.Vec3, *utils.Vec3, float64) {
+	cosMax := s.visibleCapCosine(origin)
+
+	// The polar angle's cosine is uniform in [cosMax, 1] for a uniform distribution over the cap.
+	// The cap is centered around the axis that points from the center to the origin.
+	axis := origin.Sub(s.Center).Dir()
+	axisU, axisV := axis.OrthonormalBasis()
+
+	cosTheta := rng.FloatBetween(cosMax, 1)
+	sinTheta := math.Sqrt(1 - cosTheta*cosTheta)
+	phi := rng.FloatBetween(0, 2*math.Pi)
+
+	normal := axis.Mul(cosTheta).
+		Add(axisU.Mul(sinTheta * math.Cos(phi))).
+		Add(axisV.Mul(sinTheta * math.Sin(phi)))
+	point := s.Center.Add(normal.Mul(s.Radius))
+
+	return point, normal, s.solidAnglePDF(origin, point, normal, cosMax)
+}
+
+// PDFValue returns the solid-angle probability density of SamplePoint choosing the point
+// that the given ray hits first.
+func (s *Sphere) PDFValue(origin, dir *utils.Vec3) float64 {
+	hit, isHit := s.Hit(utils.NewRay(origin, dir), utils.NewInterval(0, math.MaxFloat64))
+	if !isHit {
+		return 0
+	}
+
+	normal := hit.Point.Sub(s.Center).Dir()
+	return s.solidAnglePDF(origin, hit.Point, normal, s.visibleCapCosine(origin))
+}
+
+// SampleSurface returns a uniformly distributed random point on the whole surface of the sphere,
+// along with the outward normal there and the probability density with respect to the area,
+// which is 1 / (4π * Radius²).
+func (s *Sphere) SampleSurface(rng *random.Generator) (*utils.Vec3, *utils.Vec3, float64) {
+	normal := rng.UnitVec3()
+	point := s.Center.Add(normal.Mul(s.Radius))
+
+	return point, normal, 1 / (4 * math.Pi * s.Radius * s.Radius)
+}
+
+// SampleDirection returns a uniformly distributed random direction, from the given origin, within the cone
+// that the sphere subtends there, along with its probability density with respect to the solid angle.
+//
+// Every returned direction hits the sphere, and the density is lower-variance than that of SamplePoint.
+// If the origin lies inside the sphere, the directions are uniform over all of them.
+//
+// To know more, visit-
+// https://raytracing.github.io/books/RayTracingTheRestOfYourLife.html#cleaninguppdfmanagement/samplingasphereobject
+func (s *Sphere) SampleDirection(origin *utils.Vec3, rng *random.Generator) (*utils.Vec3, float64) {
+	cosMax := s.coneCosine(origin)
+
+	// The polar angle's cosine is uniform in [cosMax, 1] for a uniform distribution over the cone.
+	axis := s.Center.Sub(origin).Dir()
+	axisU, axisV := axis.OrthonormalBasis()
+
+	cosTheta := rng.FloatBetween(cosMax, 1)
+	sinTheta := math.Sqrt(1 - cosTheta*cosTheta)
+	phi := rng.FloatBetween(0, 2*math.Pi)
+
+	dir := axis.Mul(cosTheta).
+		Add(axisU.Mul(sinTheta * math.Cos(phi))).
+		Add(axisV.Mul(sinTheta * math.Sin(phi)))
+
+	return dir, 1 / (2 * math.Pi * (1 - cosMax))
+}
+
+// DirectionPDF returns the probability density (with respect to solid angle) with which
+// SampleDirection would choose the given direction from the given origin.
+// It is zero if the direction lies outside the cone that the sphere subtends.
+func (s *Sphere) DirectionPDF(origin, dir *utils.Vec3) float64 {
+	cosMax := s.coneCosine(origin)
+	if dir.Dir().Dot(s.Center.Sub(origin).Dir()) < cosMax {
+		return 0
+	}
+
+	return 1 / (2 * math.Pi * (1 - cosMax))
+}
+
+// coneCosine returns the cosine of the half-angle of the cone that the sphere subtends at the given origin.
+// If the origin lies inside the sphere, the cone covers all directions and the cosine is -1.
+func (s *Sphere) coneCosine(origin *utils.Vec3) float64 {
+	distanceSq := origin.DistanceSquared(s.Center)
+	radiusSq := s.Radius * s.Radius
+	if distanceSq <= radiusSq {
+		return -1
+	}
+
+	return math.Sqrt(1 - radiusSq/distanceSq)
+}
+
+// visibleCapCosine returns the cosine of the half-angle (measured at the center) of the
+// cap of the sphere that is visible from the given origin.
+// If the origin lies inside the sphere, the whole sphere is visible.
+func (s *Sphere) visibleCapCosine(origin *utils.Vec3) float64 {
+	distance := origin.Sub(s.Center).Mag()
+	if distance <= s.Radius {
+		return -1
+	}
+
+	return s.Radius / distance
+}
+
+// solidAnglePDF converts the uniform area probability density of the visible cap
+// to a solid-angle probability density as seen from the origin.
+func (s *Sphere) solidAnglePDF(origin, point, normal *utils.Vec3, cosMax float64) float64 {
+	capArea := 2 * math.Pi * s.Radius * s.Radius * (1 - cosMax)
+
+	toOrigin := origin.Sub(point)
+	distanceSq := toOrigin.DotSelf()
+	cosLight := math.Abs(normal.Dot(toOrigin.Dir()))
+	if cosLight < 1e-8 {
+		return 0
+	}
+
+	return distanceSq / (cosLight * capArea)
 }
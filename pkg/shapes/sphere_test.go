@@ -0,0 +1,187 @@
+package shapes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/mats"
+	"github.com/shivanshkc/lightshow/pkg/random"
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func BenchmarkSphere_Hit(b *testing.B) {
+	sphere := NewSphere(utils.NewVec3(0, 0, -2), 1, mats.NewMatte(utils.NewColour(0.5, 0.5, 0.5)))
+	interval := utils.NewInterval(0.001, math.MaxFloat64)
+
+	benchmarks := []struct {
+		name string
+		ray  *utils.Ray
+	}{
+		{name: "hit", ray: utils.NewRay(utils.NewVec3(0, 0, 0), utils.NewVec3(0.1, 0.1, -1))},
+		{name: "miss", ray: utils.NewRay(utils.NewVec3(0, 0, 0), utils.NewVec3(0, 1, 0))},
+	}
+
+	for _, bench := range benchmarks {
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				sphere.Hit(bench.ray, interval)
+			}
+			b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "rays/s")
+		})
+	}
+}
+
+func TestSphere_UV(t *testing.T) {
+	sphere := NewSphere(utils.NewVec3(1, 2, 3), 2, nil)
+
+	tests := []struct {
+		name    string
+		normal  *utils.Vec3
+		u, v    float64
+		tangent *utils.Vec3
+	}{
+		{name: "+X", normal: utils.NewVec3(1, 0, 0), u: 0.5, v: 0.5, tangent: utils.NewVec3(0, 0, -1)},
+		{name: "+Z", normal: utils.NewVec3(0, 0, 1), u: 0.25, v: 0.5, tangent: utils.NewVec3(1, 0, 0)},
+		{name: "-Z", normal: utils.NewVec3(0, 0, -1), u: 0.75, v: 0.5, tangent: utils.NewVec3(-1, 0, 0)},
+		{name: "+Y", normal: utils.NewVec3(0, 1, 0), u: 0.5, v: 1},
+		{name: "-Y", normal: utils.NewVec3(0, -1, 0), u: 0.5, v: 0},
+	}
+
+	for _, test := range tests {
+		// The ray comes from outside, straight toward the center.
+		origin := sphere.Center.Add(test.normal.Mul(5))
+		hit, isHit := sphere.Hit(utils.NewRay(origin, test.normal.Mul(-1)), utils.NewInterval(0, math.MaxFloat64))
+		if !isHit {
+			t.Fatalf("%s: expected a hit", test.name)
+		}
+
+		if math.Abs(hit.U-test.u) > 1e-9 || math.Abs(hit.V-test.v) > 1e-9 {
+			t.Errorf("%s: expected the UV (%v, %v), got (%v, %v)", test.name, test.u, test.v, hit.U, hit.V)
+		}
+		if test.tangent == nil && !hit.Tangent.IsNearZero() {
+			t.Errorf("%s: expected no tangent at the pole, got %v", test.name, hit.Tangent)
+		}
+		if test.tangent != nil && !hit.Tangent.ApproxEqual(test.tangent, 1e-9) {
+			t.Errorf("%s: expected the tangent %v, got %v", test.name, test.tangent, hit.Tangent)
+		}
+	}
+}
+
+func TestSphere_SamplePoint(t *testing.T) {
+	sphere := NewSphere(utils.NewVec3(1, 2, 3), 1, nil)
+	origin := utils.NewVec3(1, 2, 8)
+	rng := random.New(2)
+
+	// The points are on the cap that is visible from the origin, so the origin is in front of every one of them.
+	for i := 0; i < 10000; i++ {
+		point, normal, pdf := sphere.SamplePoint(origin, rng)
+		if math.Abs(point.Sub(sphere.Center).Mag()-1) > 1e-9 || !normal.ApproxEqual(point.Sub(sphere.Center), 1e-9) {
+			t.Fatalf("expected the point %v with the normal %v to be on the sphere", point, normal)
+		}
+		if normal.Dot(origin.Sub(point)) < -1e-9 {
+			t.Fatalf("expected the point %v to be visible from the origin", point)
+		}
+
+		// The density of the point matches that of the direction toward it. Near the silhouette,
+		// the densities are huge, so they are compared relatively.
+		if want := sphere.PDFValue(origin, point.Sub(origin)); math.Abs(pdf-want) > 1e-4*want {
+			t.Fatalf("expected the density %v of the point %v to match its direction's %v", pdf, point, want)
+		}
+	}
+
+	// The density integrates to 1 over the directions. They are picked uniformly within a cone
+	// that is wider than the sphere, so that the integral is the average density times the cone's solid angle.
+	const samples, cosMax = 200000, 0.95
+	axis := sphere.Center.Sub(origin).Dir()
+	axisU, axisV := axis.OrthonormalBasis()
+	var sum float64
+	for i := 0; i < samples; i++ {
+		cosTheta, phi := rng.FloatBetween(cosMax, 1), rng.FloatBetween(0, 2*math.Pi)
+		sinTheta := math.Sqrt(1 - cosTheta*cosTheta)
+		dir := axis.Mul(cosTheta).Add(axisU.Mul(sinTheta * math.Cos(phi))).Add(axisV.Mul(sinTheta * math.Sin(phi)))
+		sum += sphere.PDFValue(origin, dir)
+	}
+	if integral := sum / samples * 2 * math.Pi * (1 - cosMax); math.Abs(integral-1) > 0.02 {
+		t.Errorf("expected the density to integrate to 1, got %v", integral)
+	}
+
+	// The directions that miss the sphere have no density.
+	if pdf := sphere.PDFValue(origin, utils.NewVec3(0, 1, 0)); pdf != 0 {
+		t.Errorf("expected no density for a direction that misses the sphere, got %v", pdf)
+	}
+}
+
+func TestSphere_SampleDirection(t *testing.T) {
+	sphere := NewSphere(utils.NewVec3(1, 2, 3), 2, nil)
+	origin := utils.NewVec3(4, 6, 3)
+	rng := random.New(3)
+
+	// The sphere is 5 units away, so the cone's half-angle has a sine of 2/5.
+	cosMax := math.Sqrt(1 - 0.4*0.4)
+	wantPDF := 1 / (2 * math.Pi * (1 - cosMax))
+	axis := sphere.Center.Sub(origin).Dir()
+	interval := utils.NewInterval(0, math.MaxFloat64)
+
+	minCos := 1.0
+	for i := 0; i < 10000; i++ {
+		dir, pdf := sphere.SampleDirection(origin, rng)
+		cosine := dir.Dir().Dot(axis)
+		if cosine < cosMax-1e-9 {
+			t.Fatalf("expected the direction %v to be within the cone, got an angle cosine of %v", dir, cosine)
+		}
+		minCos = math.Min(minCos, cosine)
+
+		// The density is uniform over the cone's solid angle, and every direction hits the sphere.
+		if math.Abs(pdf-wantPDF) > 1e-9 || math.Abs(sphere.DirectionPDF(origin, dir)-wantPDF) > 1e-9 {
+			t.Fatalf("expected the density %v, got %v", wantPDF, pdf)
+		}
+		if _, isHit := sphere.Hit(utils.NewRay(origin, dir), interval); !isHit {
+			t.Fatalf("expected the direction %v to hit the sphere", dir)
+		}
+	}
+
+	// The directions reach the edge of the cone.
+	if minCos > cosMax+1e-3 {
+		t.Errorf("expected the directions to cover the cone, got the widest angle cosine of %v", minCos)
+	}
+	// The directions outside the cone have no density.
+	if pdf := sphere.DirectionPDF(origin, axis.Mul(-1)); pdf != 0 {
+		t.Errorf("expected no density outside the cone, got %v", pdf)
+	}
+}
+
+func TestSphere_SampleSurface(t *testing.T) {
+	sphere := NewSphere(utils.NewVec3(1, 2, 3), 2, nil)
+	rng := random.New(4)
+
+	// For a uniform distribution over a sphere, the heights and the azimuths are both uniform.
+	// So, the samples are counted in equal bins of each.
+	const samples, bins = 100000, 8
+	var heightBins, azimuthBins [bins]int
+	sumNormal := utils.NewVec3(0, 0, 0)
+	for i := 0; i < samples; i++ {
+		point, normal, pdf := sphere.SampleSurface(rng)
+		if !point.ApproxEqual(sphere.Center.Add(normal.Mul(2)), 1e-9) || math.Abs(normal.Mag()-1) > 1e-9 {
+			t.Fatalf("expected the point %v to be on the sphere, with the unit outward normal %v", point, normal)
+		}
+		if want := 1 / (16 * math.Pi); math.Abs(pdf-want) > 1e-12 {
+			t.Fatalf("expected the area density %v, got %v", want, pdf)
+		}
+
+		heightBins[int(math.Min((normal.Y+1)/2*bins, bins-1))]++
+		azimuthBins[int(math.Min((math.Atan2(normal.Z, normal.X)+math.Pi)/(2*math.Pi)*bins, bins-1))]++
+		sumNormal = sumNormal.Add(normal)
+	}
+
+	// Every bin expects 12500 samples, with a standard deviation of about 105.
+	for i := 0; i < bins; i++ {
+		if math.Abs(float64(heightBins[i])-samples/bins) > 500 || math.Abs(float64(azimuthBins[i])-samples/bins) > 500 {
+			t.Errorf("expected about %d samples in bin %d, got %d by height and %d by azimuth",
+				samples/bins, i, heightBins[i], azimuthBins[i])
+		}
+	}
+	if mean := sumNormal.Div(samples); mean.Mag() > 0.01 {
+		t.Errorf("expected the mean normal to be near zero, got %v", mean)
+	}
+}
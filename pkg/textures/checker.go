@@ -0,0 +1,34 @@
+package textures
+
+import (
+	"math"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// Checker is a texture of alternating square cells of two colours, in the UV space.
+type Checker struct {
+	// Even and Odd are the colours of the alternating cells. The cell at the UV origin is Even.
+	Even, Odd *utils.Colour
+	// Cells is the number of cells along both the U and V directions of the [0, 1] interval.
+	Cells float64
+	// Wrap determines how the UV coordinates outside the [0, 1] interval are mapped.
+	// It defaults to WrapRepeat.
+	Wrap WrapMode
+}
+
+// NewChecker returns a new Checker texture.
+func NewChecker(even, odd *utils.Colour, cells float64) *Checker {
+	return &Checker{Even: even, Odd: odd, Cells: cells}
+}
+
+func (c *Checker) Value(u, v float64, _ *utils.Vec3) *utils.Colour {
+	u, v = c.Wrap.wrap(u), c.Wrap.wrap(v)
+	// The cells at the far edges are kept whole, so that UV 1 is in the last cell instead of a new one.
+	cellU := int(math.Min(math.Floor(u*c.Cells), math.Ceil(c.Cells)-1))
+	cellV := int(math.Min(math.Floor(v*c.Cells), math.Ceil(c.Cells)-1))
+	if (cellU+cellV)%2 == 0 {
+		return c.Even
+	}
+	return c.Odd
+}
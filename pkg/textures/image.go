@@ -0,0 +1,56 @@
+package textures
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// Image is a texture that stretches an image over the UV space.
+// The top-left corner of the image is at UV (0, 1) and the bottom-left corner is at UV (0, 0).
+type Image struct {
+	// Image that gives the colours.
+	Image image.Image
+	// Wrap determines how the UV coordinates outside the [0, 1] interval are mapped.
+	// It defaults to WrapRepeat.
+	Wrap WrapMode
+}
+
+// NewImage returns a new Image texture.
+func NewImage(img image.Image) *Image {
+	return &Image{Image: img}
+}
+
+// Value returns the linear colour of the image at the given UV coordinates.
+//
+// Image files store sRGB-encoded colours, so they are decoded with the sRGB curve,
+// whatever the output colour space of the render.
+func (i *Image) Value(u, v float64, _ *utils.Vec3) *utils.Colour {
+	texel := i.texel(u, v)
+
+	linear := func(component uint16) float64 {
+		return utils.SRGBToLinear(float64(component) / math.MaxUint16)
+	}
+
+	return utils.NewColour(linear(texel.R), linear(texel.G), linear(texel.B))
+}
+
+// Alpha returns the opacity of the image at the given UV coordinates, in the [0, 1] interval.
+func (i *Image) Alpha(u, v float64) float64 {
+	return float64(i.texel(u, v).A) / math.MaxUint16
+}
+
+// texel returns the non-premultiplied colour of the image pixel at the given UV coordinates.
+func (i *Image) texel(u, v float64) color.NRGBA64 {
+	u, v = i.Wrap.wrap(u), i.Wrap.wrap(v)
+
+	bounds := i.Image.Bounds()
+	// The V coordinate goes up, while the image rows go down.
+	x := bounds.Min.X + int(math.Min(u*float64(bounds.Dx()), float64(bounds.Dx()-1)))
+	y := bounds.Min.Y + int(math.Min((1-v)*float64(bounds.Dy()), float64(bounds.Dy()-1)))
+
+	//nolint:forcetypeassert // The NRGBA64 model always returns an NRGBA64 colour.
+	return color.NRGBA64Model.Convert(i.Image.At(x, y)).(color.NRGBA64)
+}
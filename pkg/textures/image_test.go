@@ -0,0 +1,35 @@
+package textures
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestImage_Value(t *testing.T) {
+	// Texels of black, an sRGB mid grey, a dark value on the linear segment of the curve, and white.
+	components := []uint8{0, 188, 10, 255}
+	img := image.NewNRGBA(image.Rect(0, 0, len(components), 1))
+	for x, component := range components {
+		img.SetNRGBA(x, 0, color.NRGBA{R: component, G: component, B: component, A: 255})
+	}
+	texture := NewImage(img)
+
+	for x, component := range components {
+		u := (float64(x) + 0.5) / float64(len(components))
+		got := texture.Value(u, 0.5, nil)
+
+		want := utils.SRGBToLinear(float64(component) / 255)
+		if !got.ApproxEqual(utils.NewColour(want, want, want), 1e-9) {
+			t.Errorf("texel %d: expected %v, got %v", component, want, got)
+		}
+	}
+
+	// The sRGB mid grey is about half as bright as white, unlike with a plain gamma of 2.
+	if grey := texture.Value(0.375, 0.5, nil); math.Abs(grey.R-0.5) > 0.01 {
+		t.Errorf("expected the sRGB mid grey to decode to about 0.5, got %v", grey.R)
+	}
+}
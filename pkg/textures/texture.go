@@ -0,0 +1,27 @@
+package textures
+
+import (
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+// Texture gives a colour to every point on the surface of a shape.
+type Texture interface {
+	// Value returns the colour of the texture at the given UV coordinates, which lie in the [0, 1]
+	// interval for the shapes that support them, and at the given point in space.
+	Value(u, v float64, point *utils.Vec3) *utils.Colour
+}
+
+// Solid is a texture with the same colour everywhere.
+type Solid struct {
+	// Colour of the texture.
+	Colour *utils.Colour
+}
+
+// NewSolid returns a new Solid texture.
+func NewSolid(colour *utils.Colour) *Solid {
+	return &Solid{Colour: colour}
+}
+
+func (s *Solid) Value(float64, float64, *utils.Vec3) *utils.Colour {
+	return s.Colour
+}
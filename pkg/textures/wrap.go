@@ -0,0 +1,34 @@
+package textures
+
+import (
+	"math"
+)
+
+// WrapMode determines how a texture maps the UV coordinates outside the [0, 1] interval.
+type WrapMode int
+
+const (
+	// WrapRepeat tiles the texture, so that 1.25 maps to 0.25.
+	WrapRepeat WrapMode = iota
+	// WrapClamp holds the edges of the texture, so that 1.25 maps to 1.
+	WrapClamp
+	// WrapMirror tiles the texture with every other tile reflected, so that 1.25 maps to 0.75.
+	WrapMirror
+)
+
+// wrap maps the given coordinate into the [0, 1] interval.
+func (w WrapMode) wrap(coord float64) float64 {
+	switch w {
+	case WrapClamp:
+		return math.Max(0, math.Min(coord, 1))
+	case WrapMirror:
+		// Position within a pair of tiles, where the second one is reflected.
+		coord -= 2 * math.Floor(coord/2)
+		if coord > 1 {
+			return 2 - coord
+		}
+		return coord
+	default:
+		return coord - math.Floor(coord)
+	}
+}
@@ -0,0 +1,64 @@
+package textures
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/shivanshkc/lightshow/pkg/utils"
+)
+
+func TestWrapMode_Image(t *testing.T) {
+	// A row of four texels, whose red components tell them apart.
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 1))
+	for x := 0; x < 4; x++ {
+		img.SetNRGBA(x, 0, color.NRGBA{R: uint8(x * 85), A: 255})
+	}
+
+	tests := []struct {
+		name  string
+		wrap  WrapMode
+		u     float64
+		texel int
+	}{
+		{name: "repeat", wrap: WrapRepeat, u: 1.5, texel: 2},
+		{name: "clamp", wrap: WrapClamp, u: 1.5, texel: 3},
+		{name: "mirror", wrap: WrapMirror, u: 1.5, texel: 2},
+		// At 1.5, repeating and mirroring agree, so 1.25 tells them apart.
+		{name: "repeat quarter", wrap: WrapRepeat, u: 1.25, texel: 1},
+		{name: "clamp quarter", wrap: WrapClamp, u: 1.25, texel: 3},
+		{name: "mirror quarter", wrap: WrapMirror, u: 1.25, texel: 3},
+		{name: "clamp negative", wrap: WrapClamp, u: -0.5, texel: 0},
+	}
+
+	for _, test := range tests {
+		texture := &Image{Image: img, Wrap: test.wrap}
+		if got := int(texture.texel(test.u, 0.5).R>>8) / 85; got != test.texel {
+			t.Errorf("%s: expected texel %d at u = %v, got %d", test.name, test.texel, test.u, got)
+		}
+	}
+}
+
+func TestWrapMode_Checker(t *testing.T) {
+	even, odd := utils.NewColour(1, 1, 1), utils.NewColour(0, 0, 0)
+
+	tests := []struct {
+		name string
+		wrap WrapMode
+		u    float64
+		want *utils.Colour
+	}{
+		// With 4 cells, u = 0.5 is at the start of the third cell, which is even.
+		{name: "repeat", wrap: WrapRepeat, u: 1.5, want: even},
+		// The clamped u = 1 is in the last cell, which is odd.
+		{name: "clamp", wrap: WrapClamp, u: 1.5, want: odd},
+		{name: "mirror", wrap: WrapMirror, u: 1.5, want: even},
+	}
+
+	for _, test := range tests {
+		checker := &Checker{Even: even, Odd: odd, Cells: 4, Wrap: test.wrap}
+		if got := checker.Value(test.u, 0.1, nil); got != test.want {
+			t.Errorf("%s: expected %v at u = %v, got %v", test.name, test.want, test.u, got)
+		}
+	}
+}
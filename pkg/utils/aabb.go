@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"math"
+)
+
+// AABB is an axis-aligned bounding box, which is the region within an Interval along every axis.
+// It is empty if any of its intervals is empty.
+//
+// It is mainly used for quickly rejecting the rays that cannot hit the shapes inside it.
+type AABB struct {
+	X, Y, Z Interval
+}
+
+// NewAABB returns the smallest AABB that contains both the given points, which may be any two opposite corners.
+func NewAABB(a, b *Vec3) AABB {
+	return AABB{
+		X: NewInterval(math.Min(a.X, b.X), math.Max(a.X, b.X)),
+		Y: NewInterval(math.Min(a.Y, b.Y), math.Max(a.Y, b.Y)),
+		Z: NewInterval(math.Min(a.Z, b.Z), math.Max(a.Z, b.Z)),
+	}
+}
+
+// EmptyAABB returns an AABB that contains nothing. It is the starting point for the union of many boxes.
+func EmptyAABB() AABB {
+	empty := NewInterval(math.Inf(1), math.Inf(-1))
+	return AABB{X: empty, Y: empty, Z: empty}
+}
+
+// IsEmpty tells whether the box contains no points at all.
+func (a AABB) IsEmpty() bool {
+	return a.X.IsEmpty() || a.Y.IsEmpty() || a.Z.IsEmpty()
+}
+
+// Axis returns the interval of the box along the given axis, where 0, 1 and 2 are X, Y and Z.
+func (a AABB) Axis(axis int) Interval {
+	switch axis {
+	case 1:
+		return a.Y
+	case 2:
+		return a.Z
+	default:
+		return a.X
+	}
+}
+
+// Union returns the smallest box that contains both the boxes.
+func (a AABB) Union(other AABB) AABB {
+	union := func(i, j Interval) Interval {
+		return NewInterval(math.Min(i.Min, j.Min), math.Max(i.Max, j.Max))
+	}
+	return AABB{X: union(a.X, other.X), Y: union(a.Y, other.Y), Z: union(a.Z, other.Z)}
+}
+
+// AddPoint returns the smallest box that contains both the box and the given point.
+func (a AABB) AddPoint(point *Vec3) AABB {
+	return a.Union(NewAABB(point, point))
+}
+
+// Center returns the center point of the box.
+func (a AABB) Center() *Vec3 {
+	return NewVec3((a.X.Min+a.X.Max)/2, (a.Y.Min+a.Y.Max)/2, (a.Z.Min+a.Z.Max)/2)
+}
+
+// SurfaceArea returns the total area of the faces of the box. It is zero for an empty box.
+func (a AABB) SurfaceArea() float64 {
+	if a.IsEmpty() {
+		return 0
+	}
+
+	dx, dy, dz := a.X.Max-a.X.Min, a.Y.Max-a.Y.Min, a.Z.Max-a.Z.Min
+	return 2 * (dx*dy + dy*dz + dz*dx)
+}
+
+// Hit tells whether the given ray passes through the box at a distance within the given interval.
+// A box with no thickness along an axis, like that of a flat shape, can still be hit.
+//
+// It uses the slab method. To know more, visit-
+// https://raytracing.github.io/books/RayTracingTheNextWeek.html#boundingvolumehierarchies/rayintersectionwithanaabb
+func (a AABB) Hit(ray *Ray, interval Interval) bool {
+	origin := [3]float64{ray.Origin.X, ray.Origin.Y, ray.Origin.Z}
+	dir := [3]float64{ray.Dir.X, ray.Dir.Y, ray.Dir.Z}
+
+	for axis := 0; axis < 3; axis++ {
+		slab := a.Axis(axis)
+
+		// A ray parallel to the slab is either always or never within it.
+		if dir[axis] == 0 {
+			if !slab.Contains(origin[axis]) {
+				return false
+			}
+			continue
+		}
+
+		// Distances at which the ray enters and leaves the slab.
+		invDir := 1 / dir[axis]
+		t0, t1 := (slab.Min-origin[axis])*invDir, (slab.Max-origin[axis])*invDir
+		if invDir < 0 {
+			t0, t1 = t1, t0
+		}
+
+		interval = NewInterval(math.Max(t0, interval.Min), math.Min(t1, interval.Max))
+		if interval.IsEmpty() {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAABB_Hit(t *testing.T) {
+	box := NewAABB(NewVec3(1, 1, 1), NewVec3(-1, -1, -1))
+	// A box with no thickness along the Y axis, like that of a flat quad.
+	flat := NewAABB(NewVec3(-1, 0, -1), NewVec3(1, 0, 1))
+	all := NewInterval(0, math.MaxFloat64)
+
+	tests := []struct {
+		name     string
+		box      AABB
+		ray      *Ray
+		interval Interval
+		want     bool
+	}{
+		{name: "through", box: box, ray: NewRay(NewVec3(0, 0, 5), NewVec3(0, 0, -1)), interval: all, want: true},
+		{name: "diagonal", box: box, ray: NewRay(NewVec3(5, 5, 5), NewVec3(-1, -1, -1)), interval: all, want: true},
+		{name: "beside", box: box, ray: NewRay(NewVec3(2, 0, 5), NewVec3(0, 0, -1)), interval: all, want: false},
+		{name: "away", box: box, ray: NewRay(NewVec3(0, 0, 5), NewVec3(0, 0, 1)), interval: all, want: false},
+		{name: "inside", box: box, ray: NewRay(NewVec3(0, 0, 0), NewVec3(1, 2, 3)), interval: all, want: true},
+		{name: "too short", box: box, ray: NewRay(NewVec3(0, 0, 5), NewVec3(0, 0, -1)), interval: NewInterval(0, 3),
+			want: false},
+		{name: "too far", box: box, ray: NewRay(NewVec3(0, 0, 5), NewVec3(0, 0, -1)), interval: NewInterval(7, 9),
+			want: false},
+		{name: "flat", box: flat, ray: NewRay(NewVec3(0.5, 3, 0), NewVec3(0, -1, 0.1)), interval: all, want: true},
+		{name: "parallel in slab", box: flat, ray: NewRay(NewVec3(-5, 0, 0), NewVec3(1, 0, 0)), interval: all,
+			want: true},
+		{name: "parallel off slab", box: flat, ray: NewRay(NewVec3(-5, 0.1, 0), NewVec3(1, 0, 0)), interval: all,
+			want: false},
+		{name: "empty", box: EmptyAABB(), ray: NewRay(NewVec3(0, 0, 5), NewVec3(0, 0, -1)), interval: all, want: false},
+	}
+
+	for _, test := range tests {
+		if got := test.box.Hit(test.ray, test.interval); got != test.want {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestAABB_Union(t *testing.T) {
+	a := NewAABB(NewVec3(0, 0, 0), NewVec3(1, 1, 1))
+	b := NewAABB(NewVec3(-1, 0.5, 2), NewVec3(0.5, 0.5, 3))
+
+	want := NewAABB(NewVec3(-1, 0, 0), NewVec3(1, 1, 3))
+	if got := a.Union(b); got != want {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got := EmptyAABB().Union(a); got != a {
+		t.Errorf("expected the union with an empty box to be the other box, got %v", got)
+	}
+	if !EmptyAABB().IsEmpty() || a.IsEmpty() {
+		t.Errorf("expected only the empty box to be empty")
+	}
+
+	if got := want.SurfaceArea(); got != 2*(2*1+1*3+3*2) {
+		t.Errorf("expected the surface area 22, got %v", got)
+	}
+	if got := want.Center(); *got != *NewVec3(0, 0.5, 1.5) {
+		t.Errorf("expected the center (0, 0.5, 1.5), got %v", got)
+	}
+}
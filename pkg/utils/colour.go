@@ -1,8 +1,10 @@
 package utils
 
 import (
+	"encoding/json"
 	"fmt"
 	"image/color"
+	"math"
 )
 
 // Colour is an RGB colour.
@@ -15,11 +17,129 @@ func NewColour(r, g, b float64) *Colour {
 	return &Colour{r, g, b}
 }
 
+// ColourFromKelvin returns the colour of a blackbody at the given temperature in Kelvin, like 3200 for
+// warm tungsten lights and 6500 for neutral daylight. The brightest channel is about 1 and the colour is linear,
+// so it can be scaled directly for light intensities. It is meant for temperatures from 1000 to 40000.
+//
+// The fit gives sRGB display values, which are linearized with the sRGB curve.
+//
+// It uses Tanner Helland's fit of the Planckian locus. To know more, visit-
+// https://tannerhelland.com/2012/09/18/convert-temperature-rgb-algorithm-code.html
+func ColourFromKelvin(temperature float64) *Colour {
+	temp := temperature / 100
+
+	var red, green, blue float64
+	if temp <= 66 {
+		red = 255
+		green = 99.4708025861*math.Log(temp) - 161.1195681661
+	} else {
+		red = 329.698727446 * math.Pow(temp-60, -0.1332047592)
+		green = 288.1221695283 * math.Pow(temp-60, -0.0755148492)
+	}
+
+	switch {
+	case temp >= 66:
+		blue = 255
+	case temp <= 19:
+		blue = 0
+	default:
+		blue = 138.5177312231*math.Log(temp-10) - 305.0447927307
+	}
+
+	linear := func(value float64) float64 {
+		return SRGBToLinear(clamp(value, 0, 255) / 255)
+	}
+
+	return NewColour(linear(red), linear(green), linear(blue))
+}
+
+// String formats the colour as "rgb(r, g, b)".
+func (c *Colour) String() string {
+	return fmt.Sprintf("rgb(%.3f, %.3f, %.3f)", c.R, c.G, c.B)
+}
+
+// MarshalJSON encodes the colour as a [r, g, b] array.
+//
+// It has a value receiver so that it also applies to non-pointer colours.
+func (c Colour) MarshalJSON() ([]byte, error) {
+	return json.Marshal([3]float64{c.R, c.G, c.B})
+}
+
+// UnmarshalJSON decodes the colour from a [r, g, b] array.
+func (c *Colour) UnmarshalJSON(data []byte) error {
+	var components []float64
+	if err := json.Unmarshal(data, &components); err != nil {
+		return fmt.Errorf("failed to decode colour: %w", err)
+	}
+	if len(components) != 3 {
+		return fmt.Errorf("colour must have 3 components, found %d", len(components))
+	}
+
+	c.R, c.G, c.B = components[0], components[1], components[2]
+	return nil
+}
+
 // Add adds the given colour to the colour and returns the result.
 func (c *Colour) Add(arg *Colour) *Colour {
 	return NewColour(c.R+arg.R, c.G+arg.G, c.B+arg.B)
 }
 
+// Attenuate multiplies the colour with the given colour component-wise and returns the result.
+func (c *Colour) Attenuate(arg *Colour) *Colour {
+	return NewColour(c.R*arg.R, c.G*arg.G, c.B*arg.B)
+}
+
+// Scale multiplies all components of the colour with the given factor and returns the result.
+func (c *Colour) Scale(factor float64) *Colour {
+	return NewColour(c.R*factor, c.G*factor, c.B*factor)
+}
+
+// DivScalar divides all components of the colour by the given divisor and returns the result.
+func (c *Colour) DivScalar(divisor float64) *Colour {
+	return c.Scale(1 / divisor)
+}
+
+// Luminance returns the perceived brightness of the colour, using the Rec. 709 weights.
+// To know more, visit-
+// https://en.wikipedia.org/wiki/Relative_luminance
+func (c *Colour) Luminance() float64 {
+	return 0.2126*c.R + 0.7152*c.G + 0.0722*c.B
+}
+
+// ClampPreservingHue brings all components of the colour into the [0, 1] interval and returns the result.
+//
+// Unlike clamping every component independently, which turns over-bright saturated colours toward
+// an odd hue (an over-bright orange turns yellow, for example), it desaturates the colour toward
+// the gray of the same luminance, only as much as needed, so the hue stays the same.
+// Colours brighter than white become white.
+func (c *Colour) ClampPreservingHue() *Colour {
+	clamped := NewColour(math.Max(c.R, 0), math.Max(c.G, 0), math.Max(c.B, 0))
+
+	highest := math.Max(clamped.R, math.Max(clamped.G, clamped.B))
+	if highest <= 1 {
+		return clamped
+	}
+
+	gray := clamped.Luminance()
+	if gray >= 1 {
+		return NewColour(1, 1, 1)
+	}
+
+	// Fraction of the distance from the gray that keeps the highest component at 1.
+	fraction := (1 - gray) / (highest - gray)
+	return NewColour(
+		gray+(clamped.R-gray)*fraction,
+		gray+(clamped.G-gray)*fraction,
+		gray+(clamped.B-gray)*fraction,
+	)
+}
+
+// ApproxEqual returns true if all components of the colour differ from the given colour's by at most eps.
+// A NaN component is never equal to anything.
+func (c *Colour) ApproxEqual(arg *Colour, eps float64) bool {
+	return approxEqual(c.R, arg.R, eps) && approxEqual(c.G, arg.G, eps) && approxEqual(c.B, arg.B, eps)
+}
+
 // Lerp stands for Linear Interpolation.
 //
 // It is mainly used for blending two colours smoothly.
@@ -27,8 +147,11 @@ func (c *Colour) Add(arg *Colour) *Colour {
 // The formula for linear interpolation is given by:
 // final = (1 - x) * start + x * end.
 func (c *Colour) Lerp(end *Colour, x float64) *Colour {
-	// TODO: Is this extensive chaining a performance concern?
-	return c.ToVec3().Lerp(end.ToVec3(), x).ToColour()
+	return NewColour(
+		(1-x)*c.R+x*end.R,
+		(1-x)*c.G+x*end.G,
+		(1-x)*c.B+x*end.B,
+	)
 }
 
 // ToVec3 converts this Colour to a Vec3 type by mapping
@@ -47,6 +170,16 @@ func (c *Colour) ToStd() color.Color {
 	}
 }
 
+// ToStd64 provides the standard library 16-bit colour instance for this colour.
+func (c *Colour) ToStd64() color.RGBA64 {
+	return color.RGBA64{
+		uint16(65536 * clamp(c.R, 0, 0.99999)),
+		uint16(65536 * clamp(c.G, 0, 0.99999)),
+		uint16(65536 * clamp(c.B, 0, 0.99999)),
+		65535,
+	}
+}
+
 // ToPPM converts the colour to a row of the PPM image format.
 // The format of the row is nothing but "<0-255> <0-255> <0-255>".
 func (c *Colour) ToPPM() string {
@@ -58,6 +191,24 @@ func (c *Colour) ToPPM() string {
 	)
 }
 
+// SRGBToLinear converts a colour component encoded with the sRGB transfer function, like those in image files,
+// to linear. To know more, visit-
+// https://en.wikipedia.org/wiki/SRGB#Transfer_function_(%22gamma%22)
+func SRGBToLinear(value float64) float64 {
+	if value <= 0.04045 {
+		return value / 12.92
+	}
+	return math.Pow((value+0.055)/1.055, 2.4)
+}
+
+// LinearToSRGB converts a linear colour component to the sRGB transfer function. It is the inverse of SRGBToLinear.
+func LinearToSRGB(value float64) float64 {
+	if value <= 0.0031308 {
+		return 12.92 * value
+	}
+	return 1.055*math.Pow(value, 1/2.4) - 0.055
+}
+
 // clamp the given value between min and max.
 //
 //nolint:unparam
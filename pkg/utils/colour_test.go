@@ -0,0 +1,224 @@
+package utils
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestColour_ApproxEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *Colour
+		eps  float64
+		want bool
+	}{
+		{name: "identical", a: NewColour(0.25, 0.5, 0.75), b: NewColour(0.25, 0.5, 0.75), eps: 0, want: true},
+		// The differences are exactly representable, so the comparison is exact.
+		{name: "exactly eps", a: NewColour(0.25, 0.5, 0.75), b: NewColour(0.5, 0.25, 0.5), eps: 0.25, want: true},
+		{name: "just beyond eps", a: NewColour(0.25, 0.5, 0.75), b: NewColour(0.25, 0.5, math.Nextafter(1, 2)),
+			eps: 0.25, want: false},
+		{name: "NaN in first", a: NewColour(0.25, math.NaN(), 0.75), b: NewColour(0.25, 0.5, 0.75), eps: 1, want: false},
+		{name: "NaN in second", a: NewColour(0.25, 0.5, 0.75), b: NewColour(math.NaN(), 0.5, 0.75), eps: 1, want: false},
+	}
+
+	for _, test := range tests {
+		if got := test.a.ApproxEqual(test.b, test.eps); got != test.want {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestSRGBToLinear(t *testing.T) {
+	tests := []struct {
+		encoded, linear float64
+	}{
+		{encoded: 0, linear: 0},
+		{encoded: 1, linear: 1},
+		// On the linear segment near black.
+		{encoded: 0.04, linear: 0.04 / 12.92},
+		// On the power segment.
+		{encoded: 0.5, linear: 0.21404114048223255},
+	}
+
+	for _, test := range tests {
+		if got := SRGBToLinear(test.encoded); math.Abs(got-test.linear) > 1e-12 {
+			t.Errorf("%v: expected %v, got %v", test.encoded, test.linear, got)
+		}
+		if got := LinearToSRGB(test.linear); math.Abs(got-test.encoded) > 1e-12 {
+			t.Errorf("%v: expected the inverse %v, got %v", test.linear, test.encoded, got)
+		}
+	}
+}
+
+func TestColourFromKelvin(t *testing.T) {
+	// Daylight is close to white.
+	daylight := ColourFromKelvin(6500)
+	if !daylight.ApproxEqual(NewColour(1, 1, 1), 0.05) {
+		t.Errorf("expected 6500K to be near white, got %v", daylight)
+	}
+
+	// Candlelight is strongly red and orange, with little blue.
+	candle := ColourFromKelvin(2000)
+	if candle.R < 0.99 || candle.G > 0.4 || candle.B > 0.1 || candle.G < candle.B {
+		t.Errorf("expected 2000K to be strongly red and orange, got %v", candle)
+	}
+}
+
+func TestColour_String(t *testing.T) {
+	tests := []struct {
+		c    *Colour
+		want string
+	}{
+		{c: NewColour(0, 0, 0), want: "rgb(0.000, 0.000, 0.000)"},
+		{c: NewColour(1, 0.5, 0.25), want: "rgb(1.000, 0.500, 0.250)"},
+		// Three decimals are kept, and the components are not clamped.
+		{c: NewColour(0.12345, 4, -0.5), want: "rgb(0.123, 4.000, -0.500)"},
+	}
+
+	for _, test := range tests {
+		if got := test.c.String(); got != test.want {
+			t.Errorf("expected %q, got %q", test.want, got)
+		}
+	}
+}
+
+func TestColour_JSON(t *testing.T) {
+	for _, c := range []Colour{{0, 0, 0}, {1, 0.5, 0.25}, {4, 1e-9, -0.5}} {
+		data, err := json.Marshal(c)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", c, err)
+		}
+		// Pointers are encoded the same way.
+		if pointerData, _ := json.Marshal(&c); string(pointerData) != string(data) {
+			t.Errorf("%v: expected the pointer to encode as %s, got %s", c, data, pointerData)
+		}
+
+		var decoded Colour
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("%v: unexpected error: %v", c, err)
+		}
+		if decoded != c {
+			t.Errorf("expected %v to survive the round trip through %s, got %v", c, data, decoded)
+		}
+	}
+
+	if data, _ := json.Marshal(NewColour(1, 0.5, 0)); string(data) != "[1,0.5,0]" {
+		t.Errorf("expected the colour to encode as an array, got %s", data)
+	}
+
+	for _, data := range []string{`[1, 2]`, `[1, 2, 3, 4]`, `{"R": 1}`} {
+		if err := json.Unmarshal([]byte(data), &Colour{}); err == nil {
+			t.Errorf("%s: expected an error", data)
+		}
+	}
+
+	want := "colour must have 3 components, found 2"
+	if err := json.Unmarshal([]byte(`[1, 2]`), &Colour{}); err == nil || err.Error() != want {
+		t.Errorf("expected the error %q, got %v", want, err)
+	}
+}
+
+func TestColour_Luminance(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *Colour
+		want float64
+	}{
+		{name: "black", c: NewColour(0, 0, 0), want: 0},
+		{name: "white", c: NewColour(1, 1, 1), want: 1},
+		{name: "red", c: NewColour(1, 0, 0), want: 0.2126},
+		{name: "green", c: NewColour(0, 1, 0), want: 0.7152},
+		{name: "blue", c: NewColour(0, 0, 1), want: 0.0722},
+		// Grays have the luminance of their channels, even beyond white.
+		{name: "gray", c: NewColour(0.3, 0.3, 0.3), want: 0.3},
+		{name: "bright gray", c: NewColour(5, 5, 5), want: 5},
+		{name: "mixed", c: NewColour(0.5, 0.25, 2), want: 0.2126*0.5 + 0.7152*0.25 + 0.0722*2},
+	}
+
+	for _, test := range tests {
+		if got := test.c.Luminance(); math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+	}
+
+	// Green looks brighter than red, which looks brighter than blue.
+	red, green, blue := NewColour(1, 0, 0).Luminance(), NewColour(0, 1, 0).Luminance(), NewColour(0, 0, 1).Luminance()
+	if !(green > red && red > blue) {
+		t.Errorf("expected green %v > red %v > blue %v", green, red, blue)
+	}
+}
+
+func TestColour_ScaleAndDivScalar(t *testing.T) {
+	c := NewColour(0.5, 0.25, 2)
+
+	tests := []struct {
+		name      string
+		got, want *Colour
+	}{
+		{name: "scale by zero", got: c.Scale(0), want: NewColour(0, 0, 0)},
+		{name: "scale by one", got: c.Scale(1), want: c},
+		{name: "scale by more than one", got: c.Scale(4), want: NewColour(2, 1, 8)},
+		{name: "scale by a fraction", got: c.Scale(0.5), want: NewColour(0.25, 0.125, 1)},
+		{name: "scale by a negative", got: c.Scale(-2), want: NewColour(-1, -0.5, -4)},
+		{name: "divide by one", got: c.DivScalar(1), want: c},
+		{name: "divide by more than one", got: c.DivScalar(4), want: NewColour(0.125, 0.0625, 0.5)},
+		{name: "divide by a fraction", got: c.DivScalar(0.5), want: NewColour(1, 0.5, 4)},
+	}
+
+	for _, test := range tests {
+		if !test.got.ApproxEqual(test.want, 1e-12) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, test.got)
+		}
+	}
+
+	// Dividing by zero gives infinities, like the float division.
+	if got := c.DivScalar(0); !math.IsInf(got.R, 1) || !math.IsInf(got.G, 1) || !math.IsInf(got.B, 1) {
+		t.Errorf("divide by zero: expected infinite components, got %v", got)
+	}
+
+	// The original colour is not modified.
+	if *c != (Colour{0.5, 0.25, 2}) {
+		t.Errorf("expected the colour to stay the same, got %v", c)
+	}
+}
+
+func TestColour_ClampPreservingHue(t *testing.T) {
+	// The hue angle of the colour, in degrees. To know more, visit-
+	// https://en.wikipedia.org/wiki/Hue#Defining_hue_in_terms_of_RGB
+	hue := func(c *Colour) float64 {
+		return math.Atan2(math.Sqrt(3)*(c.G-c.B), 2*c.R-c.G-c.B) * 180 / math.Pi
+	}
+	// The per-channel clamp, for comparison.
+	clampChannels := func(c *Colour) *Colour {
+		return NewColour(math.Min(math.Max(c.R, 0), 1), math.Min(math.Max(c.G, 0), 1), math.Min(math.Max(c.B, 0), 1))
+	}
+
+	// Over-bright saturated colours, which are darker than white.
+	for _, c := range []*Colour{NewColour(1.6, 0.5, 0.1), NewColour(1.4, 0.2, 0.8), NewColour(0.2, 0.4, 1.8)} {
+		got := c.ClampPreservingHue()
+		if got.R < 0 || got.R > 1+1e-12 || got.G < 0 || got.G > 1+1e-12 || got.B < 0 || got.B > 1+1e-12 {
+			t.Errorf("%v: expected the components in [0, 1], got %v", c, got)
+		}
+		if math.Abs(hue(got)-hue(c)) > 1e-9 {
+			t.Errorf("%v: expected the hue %v to be kept, got %v", c, hue(c), hue(got))
+		}
+		if shift := math.Abs(hue(clampChannels(c)) - hue(c)); shift < 1 {
+			t.Errorf("%v: expected the per-channel clamp to shift the hue, got a shift of %v", c, shift)
+		}
+	}
+
+	// The per-channel clamp turns the over-bright orange toward yellow, whose hue is 60 degrees.
+	orange := NewColour(1.6, 0.5, 0.1)
+	if got := hue(clampChannels(orange)); got < hue(orange)+10 {
+		t.Errorf("expected the per-channel clamp to turn the orange toward yellow, got the hue %v", got)
+	}
+
+	// The colours within the range are kept, and those brighter than white become white.
+	if c := NewColour(0.25, 0.5, 1); !c.ClampPreservingHue().ApproxEqual(c, 0) {
+		t.Errorf("expected %v to be kept, got %v", c, c.ClampPreservingHue())
+	}
+	if got := NewColour(4, 3, 2).ClampPreservingHue(); !got.ApproxEqual(NewColour(1, 1, 1), 0) {
+		t.Errorf("expected the colour brighter than white to become white, got %v", got)
+	}
+}
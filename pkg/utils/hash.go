@@ -0,0 +1,159 @@
+package utils
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+)
+
+// Hash returns a stable hash of the given values, like shapes, materials or their combinations.
+//
+// Two values hash the same if they have the same types and all their fields (exported or not)
+// are equal, following pointers and interfaces. So, it can be used to tell whether a scene changed.
+//
+// Values that cannot be inspected, like functions and channels, only contribute whether they are nil.
+// Synchronization primitives, like the mutex of a group, are ignored.
+func Hash(values ...interface{}) uint64 {
+	hasher := newValueHasher()
+	for _, value := range values {
+		hasher.write(reflect.ValueOf(value))
+	}
+
+	return hasher.hash.Sum64()
+}
+
+// ErrUnhashable is returned by HashStrict for the values that cannot be inspected.
+var ErrUnhashable = errors.New("value cannot be hashed")
+
+// HashStrict is like Hash, except that it returns ErrUnhashable if the values hold a non-nil function
+// or channel, instead of letting all of them hash the same.
+func HashStrict(values ...interface{}) (uint64, error) {
+	hasher := newValueHasher()
+	for _, value := range values {
+		hasher.write(reflect.ValueOf(value))
+	}
+
+	if hasher.opaque != nil {
+		return 0, fmt.Errorf("%w: it holds a %s", ErrUnhashable, hasher.opaque)
+	}
+
+	return hasher.hash.Sum64(), nil
+}
+
+// valueHasher recursively writes values into a hash.
+type valueHasher struct {
+	hash hash.Hash64
+	// visited records the pointers that are being written, to avoid infinite recursion on cycles.
+	visited map[uintptr]bool
+	// opaque is the type of the first non-nil value that could not be inspected, if any.
+	opaque reflect.Type
+}
+
+// newValueHasher returns a new valueHasher.
+func newValueHasher() *valueHasher {
+	return &valueHasher{hash: fnv.New64a(), visited: map[uintptr]bool{}}
+}
+
+// write writes the given value, along with its type, into the hash.
+func (v *valueHasher) write(value reflect.Value) {
+	if !value.IsValid() {
+		v.writeUint(0)
+		return
+	}
+
+	v.writeString(value.Type().String())
+
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			v.writeUint(1)
+		} else {
+			v.writeUint(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.writeUint(uint64(value.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.writeUint(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		v.writeUint(math.Float64bits(value.Float()))
+	case reflect.String:
+		v.writeString(value.String())
+	case reflect.Pointer:
+		if value.IsNil() {
+			v.writeUint(0)
+			return
+		}
+		if v.visited[value.Pointer()] {
+			v.writeUint(1)
+			return
+		}
+		// Only the pointers on the current path are tracked, so that shared values (like a material
+		// used by many shapes) hash the same as their copies.
+		v.visited[value.Pointer()] = true
+		v.write(value.Elem())
+		delete(v.visited, value.Pointer())
+	case reflect.Interface:
+		v.write(value.Elem())
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			if !isSyncType(value.Field(i).Type()) {
+				v.write(value.Field(i))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		v.writeUint(uint64(value.Len()))
+		for i := 0; i < value.Len(); i++ {
+			v.write(value.Index(i))
+		}
+	case reflect.Map:
+		// The iteration order of maps is random, so the entries are combined in an order-independent way.
+		var sum uint64
+		for iter := value.MapRange(); iter.Next(); {
+			entry := &valueHasher{hash: fnv.New64a(), visited: v.visited}
+			entry.write(iter.Key())
+			entry.write(iter.Value())
+			sum += entry.hash.Sum64()
+			if v.opaque == nil {
+				v.opaque = entry.opaque
+			}
+		}
+		v.writeUint(sum)
+	default:
+		// Functions, channels and unsafe pointers cannot be inspected.
+		if value.IsNil() {
+			v.writeUint(0)
+			return
+		}
+		v.writeUint(1)
+		if v.opaque == nil {
+			v.opaque = value.Type()
+		}
+	}
+}
+
+// writeUint writes the given number into the hash.
+func (v *valueHasher) writeUint(num uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], num)
+	_, _ = v.hash.Write(buf[:])
+}
+
+// writeString writes the given string into the hash, prefixed by its length to avoid ambiguity.
+func (v *valueHasher) writeString(str string) {
+	v.writeUint(uint64(len(str)))
+	_, _ = v.hash.Write([]byte(str))
+}
+
+// isSyncType tells whether the given type, or the type it points to, belongs to the sync or the sync/atomic
+// package, like sync.RWMutex or *sync.Mutex.
+func isSyncType(typ reflect.Type) bool {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	return typ.PkgPath() == "sync" || typ.PkgPath() == "sync/atomic"
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestHash_SyncTypes(t *testing.T) {
+	type guarded struct {
+		Value   float64
+		mutex   sync.RWMutex
+		pointer *sync.Mutex
+		counter atomic.Int64
+	}
+
+	first, second := &guarded{Value: 1, pointer: &sync.Mutex{}}, &guarded{Value: 1}
+	second.mutex.Lock()
+	second.counter.Add(5)
+	defer second.mutex.Unlock()
+
+	if Hash(first) != Hash(second) {
+		t.Errorf("expected the synchronization primitives to be ignored")
+	}
+
+	second.Value = 2
+	if Hash(first) == Hash(second) {
+		t.Errorf("expected a different value to change the hash")
+	}
+}
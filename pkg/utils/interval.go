@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"math"
+)
+
+// Interval represents a range of real numbers between Min and Max.
+// It is empty if Min is greater than Max.
+//
+// It is mainly used for the range of distances along a ray in which hits are registered.
+type Interval struct {
+	Min, Max float64
+}
+
+// NewInterval returns a new Interval between the given min and max.
+func NewInterval(min, max float64) Interval {
+	return Interval{Min: min, Max: max}
+}
+
+// IsEmpty tells whether the interval contains no numbers at all.
+func (i Interval) IsEmpty() bool {
+	return i.Min > i.Max
+}
+
+// Contains tells whether the given value lies within the interval, including its ends.
+func (i Interval) Contains(value float64) bool {
+	return i.Min <= value && value <= i.Max
+}
+
+// Surrounds tells whether the given value lies within the interval, excluding its ends.
+func (i Interval) Surrounds(value float64) bool {
+	return i.Min < value && value < i.Max
+}
+
+// Overlaps tells whether the interval and the given one have at least one number in common.
+// Empty intervals overlap nothing.
+func (i Interval) Overlaps(other Interval) bool {
+	return math.Max(i.Min, other.Min) <= math.Min(i.Max, other.Max)
+}
+
+// Clamp returns the number within the interval that is closest to the given value.
+// The interval is expected to be non-empty.
+func (i Interval) Clamp(value float64) float64 {
+	return math.Min(math.Max(value, i.Min), i.Max)
+}
+
+// Expand returns the interval grown by the given amount, half on each side.
+// A negative amount shrinks the interval.
+func (i Interval) Expand(amount float64) Interval {
+	return Interval{Min: i.Min - amount/2, Max: i.Max + amount/2}
+}
+
+// WithMax returns a copy of the interval with the given max.
+func (i Interval) WithMax(max float64) Interval {
+	return Interval{Min: i.Min, Max: max}
+}
@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestInterval_IsEmpty(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval Interval
+		want     bool
+	}{
+		{name: "regular", interval: NewInterval(1, 2), want: false},
+		{name: "single point", interval: NewInterval(1, 1), want: false},
+		{name: "inverted", interval: NewInterval(2, 1), want: true},
+	}
+
+	for _, test := range tests {
+		if got := test.interval.IsEmpty(); got != test.want {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestInterval_Contains(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval Interval
+		value    float64
+		want     bool
+	}{
+		{name: "inside", interval: NewInterval(1, 2), value: 1.5, want: true},
+		{name: "at min", interval: NewInterval(1, 2), value: 1, want: true},
+		{name: "at max", interval: NewInterval(1, 2), value: 2, want: true},
+		{name: "below", interval: NewInterval(1, 2), value: 0.5, want: false},
+		{name: "above", interval: NewInterval(1, 2), value: 2.5, want: false},
+		{name: "inverted", interval: NewInterval(2, 1), value: 1.5, want: false},
+	}
+
+	for _, test := range tests {
+		if got := test.interval.Contains(test.value); got != test.want {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestInterval_Surrounds(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval Interval
+		value    float64
+		want     bool
+	}{
+		{name: "inside", interval: NewInterval(1, 2), value: 1.5, want: true},
+		{name: "at min", interval: NewInterval(1, 2), value: 1, want: false},
+		{name: "at max", interval: NewInterval(1, 2), value: 2, want: false},
+		{name: "single point", interval: NewInterval(1, 1), value: 1, want: false},
+		{name: "inverted", interval: NewInterval(2, 1), value: 1.5, want: false},
+	}
+
+	for _, test := range tests {
+		if got := test.interval.Surrounds(test.value); got != test.want {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestInterval_Overlaps(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Interval
+		want bool
+	}{
+		{name: "partial", a: NewInterval(1, 3), b: NewInterval(2, 4), want: true},
+		{name: "nested", a: NewInterval(1, 4), b: NewInterval(2, 3), want: true},
+		{name: "touching", a: NewInterval(1, 2), b: NewInterval(2, 3), want: true},
+		{name: "disjoint", a: NewInterval(1, 2), b: NewInterval(3, 4), want: false},
+		{name: "first inverted", a: NewInterval(3, 1), b: NewInterval(0, 4), want: false},
+		{name: "second inverted", a: NewInterval(0, 4), b: NewInterval(3, 1), want: false},
+	}
+
+	for _, test := range tests {
+		if got := test.a.Overlaps(test.b); got != test.want {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+		// Overlapping is symmetric.
+		if got := test.b.Overlaps(test.a); got != test.want {
+			t.Errorf("%s (swapped): expected %v, got %v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestInterval_Clamp(t *testing.T) {
+	interval := NewInterval(1, 2)
+
+	for value, want := range map[float64]float64{0: 1, 1: 1, 1.5: 1.5, 2: 2, 3: 2} {
+		if got := interval.Clamp(value); got != want {
+			t.Errorf("clamping %v: expected %v, got %v", value, want, got)
+		}
+	}
+}
+
+func TestInterval_Expand(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval Interval
+		amount   float64
+		want     Interval
+	}{
+		{name: "grow", interval: NewInterval(1, 2), amount: 1, want: NewInterval(0.5, 2.5)},
+		{name: "shrink", interval: NewInterval(1, 2), amount: -0.5, want: NewInterval(1.25, 1.75)},
+		{name: "shrink to empty", interval: NewInterval(1, 2), amount: -2, want: NewInterval(2, 1)},
+		{name: "grow inverted", interval: NewInterval(2, 1), amount: 2, want: NewInterval(1, 2)},
+	}
+
+	for _, test := range tests {
+		if got := test.interval.Expand(test.amount); got != test.want {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+	}
+
+	// Shrinking by more than the size empties the interval.
+	if !NewInterval(1, 2).Expand(-2).IsEmpty() {
+		t.Errorf("expected the over-shrunk interval to be empty")
+	}
+}
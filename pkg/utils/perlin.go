@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"math"
+	"math/rand"
+)
+
+// perlinSize is the number of lattice points after which the Perlin noise repeats along every axis.
+const perlinSize = 256
+
+// Perlin generates Perlin noise, which is smooth and natural-looking, like that of terrain or clouds.
+//
+// It uses Ken Perlin's improved noise, with a permutation of the lattice points given by a seed.
+// To know more, visit-
+// https://mrl.cs.nyu.edu/~perlin/noise/
+type Perlin struct {
+	// permutation holds the shuffled lattice indices twice, to avoid wrapping the indices.
+	permutation [2 * perlinSize]int
+}
+
+// NewPerlin returns a new Perlin noise generator. The same seed always gives the same noise.
+func NewPerlin(seed int64) *Perlin {
+	p := &Perlin{}
+
+	//nolint:gosec // The noise does not need a cryptographic generator.
+	shuffled := rand.New(rand.NewSource(seed)).Perm(perlinSize)
+	for i := range p.permutation {
+		p.permutation[i] = shuffled[i%perlinSize]
+	}
+
+	return p
+}
+
+// Noise returns the noise at the given point, roughly in the [-1, 1] interval. It is zero at all integer points.
+func (p *Perlin) Noise(point *Vec3) float64 {
+	// The lattice cell of the point and the position of the point within it.
+	floorX, floorY, floorZ := math.Floor(point.X), math.Floor(point.Y), math.Floor(point.Z)
+	x, y, z := point.X-floorX, point.Y-floorY, point.Z-floorZ
+	cellX, cellY, cellZ := wrapLattice(floorX), wrapLattice(floorY), wrapLattice(floorZ)
+
+	// The hashes of the corners of the cell.
+	perm := &p.permutation
+	a := perm[cellX] + cellY
+	aa, ab := perm[a]+cellZ, perm[a+1]+cellZ
+	b := perm[cellX+1] + cellY
+	ba, bb := perm[b]+cellZ, perm[b+1]+cellZ
+
+	// Blend the gradients of the corners with a smooth curve.
+	u, v, w := fade(x), fade(y), fade(z)
+	return lerp(w,
+		lerp(v,
+			lerp(u, gradient(perm[aa], x, y, z), gradient(perm[ba], x-1, y, z)),
+			lerp(u, gradient(perm[ab], x, y-1, z), gradient(perm[bb], x-1, y-1, z))),
+		lerp(v,
+			lerp(u, gradient(perm[aa+1], x, y, z-1), gradient(perm[ba+1], x-1, y, z-1)),
+			lerp(u, gradient(perm[ab+1], x, y-1, z-1), gradient(perm[bb+1], x-1, y-1, z-1))))
+}
+
+// Fractal returns the sum of the given number of octaves of noise at the given point, which is also called
+// fractal Brownian motion. Every octave has twice the frequency and half the amplitude of the previous one,
+// which adds finer details. The result is roughly in the [-1, 1] interval.
+func (p *Perlin) Fractal(point *Vec3, octaves int) float64 {
+	sum, amplitude, total := 0.0, 1.0, 0.0
+	for octave := 0; octave < octaves; octave++ {
+		sum += amplitude * p.Noise(point)
+		total += amplitude
+
+		point = point.Mul(2)
+		amplitude /= 2
+	}
+
+	if total == 0 {
+		return 0
+	}
+	return sum / total
+}
+
+// wrapLattice returns the index of the given lattice coordinate within the repeating lattice.
+func wrapLattice(coordinate float64) int {
+	index := int(math.Mod(coordinate, perlinSize))
+	if index < 0 {
+		index += perlinSize
+	}
+	return index
+}
+
+// fade is the smooth curve 6t^5 - 15t^4 + 10t^3, whose first and second derivatives are zero at 0 and 1.
+func fade(t float64) float64 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
+
+// lerp interpolates linearly from a to b by the given fraction.
+func lerp(fraction, a, b float64) float64 {
+	return a + fraction*(b-a)
+}
+
+// gradient returns the dot product of the given offset with one of 12 gradient directions, picked by the hash.
+func gradient(hash int, x, y, z float64) float64 {
+	h := hash & 15
+
+	u := y
+	if h < 8 {
+		u = x
+	}
+
+	var v float64
+	switch {
+	case h < 4:
+		v = y
+	case h == 12 || h == 14:
+		v = x
+	default:
+		v = z
+	}
+
+	if h&1 != 0 {
+		u = -u
+	}
+	if h&2 != 0 {
+		v = -v
+	}
+	return u + v
+}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPerlin_Noise(t *testing.T) {
+	perlin := NewPerlin(1)
+
+	// The noise is zero at the lattice points.
+	for _, point := range []*Vec3{NewVec3(0, 0, 0), NewVec3(3, -7, 12), NewVec3(-300, 1, 256)} {
+		if noise := perlin.Noise(point); noise != 0 {
+			t.Errorf("expected zero noise at %v, got %v", point, noise)
+		}
+	}
+
+	// The same seed gives the same noise, and a different seed gives a different one.
+	point := NewVec3(1.3, 2.7, -0.4)
+	if NewPerlin(1).Noise(point) != perlin.Noise(point) {
+		t.Errorf("expected the same noise for the same seed")
+	}
+	if NewPerlin(2).Noise(point) == perlin.Noise(point) {
+		t.Errorf("expected a different noise for a different seed")
+	}
+
+	// The noise is continuous and stays roughly within [-1, 1].
+	for i := 0; i < 1000; i++ {
+		point := NewVec3(float64(i)*0.137, float64(i)*0.071, float64(i)*-0.053)
+		noise, nearby := perlin.Noise(point), perlin.Noise(point.Add(NewVec3(1e-6, 0, 0)))
+		if math.Abs(noise) > 1 || math.Abs(noise-nearby) > 1e-4 {
+			t.Fatalf("expected a small, continuous noise at %v, got %v and %v nearby", point, noise, nearby)
+		}
+	}
+}
+
+func TestPerlin_Fractal(t *testing.T) {
+	perlin := NewPerlin(1)
+	point := NewVec3(1.3, 2.7, -0.4)
+
+	// A single octave is the noise itself.
+	if perlin.Fractal(point, 1) != perlin.Noise(point) {
+		t.Errorf("expected a single octave to equal the noise")
+	}
+	if perlin.Fractal(point, 0) != 0 {
+		t.Errorf("expected no octaves to give zero")
+	}
+	if fractal := perlin.Fractal(point, 5); math.Abs(fractal) > 1 {
+		t.Errorf("expected the fractal noise within [-1, 1], got %v", fractal)
+	}
+}
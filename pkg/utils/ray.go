@@ -3,6 +3,12 @@ package utils
 // Ray represents a ray of light.
 type Ray struct {
 	Origin, Dir *Vec3
+	// Time at which the ray exists, for the shapes that change over time.
+	// It lies between the camera's shutter open and close times, and is zero by default.
+	Time float64
+	// Channel is the only colour channel that the ray carries, after a dispersive material has split
+	// the white light into its colours. It is 1 for red, 2 for green and 3 for blue. Zero means all channels.
+	Channel int
 }
 
 // NewRay returns a new ray instance.
@@ -10,8 +16,11 @@ func NewRay(origin, dir *Vec3) *Ray {
 	return &Ray{Origin: origin, Dir: dir.Dir()}
 }
 
-// Point returns a point on the ray that is given distance
-// away from the ray's origin.
-func (r *Ray) Point(distance float64) *Vec3 {
+// At returns the point on the ray that is the given distance away from the ray's origin,
+// that is, Origin + Dir * distance.
+//
+// Since Dir is a unit vector, the distance is in world units. A negative distance gives
+// a point behind the origin.
+func (r *Ray) At(distance float64) *Vec3 {
 	return r.Origin.Add(r.Dir.Mul(distance))
 }
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRay_At(t *testing.T) {
+	// The direction is normalised, so the distances are in world units.
+	ray := NewRay(NewVec3(1, 2, 3), NewVec3(0, 0, 4))
+
+	tests := []struct {
+		distance float64
+		want     *Vec3
+	}{
+		{distance: 0, want: NewVec3(1, 2, 3)},
+		{distance: 1, want: NewVec3(1, 2, 4)},
+		{distance: 2.5, want: NewVec3(1, 2, 5.5)},
+		// Negative distances are behind the origin.
+		{distance: -1, want: NewVec3(1, 2, 2)},
+		{distance: -10, want: NewVec3(1, 2, -7)},
+	}
+
+	for _, test := range tests {
+		if got := ray.At(test.distance); !got.ApproxEqual(test.want, 1e-12) {
+			t.Errorf("%v: expected %v, got %v", test.distance, test.want, got)
+		}
+	}
+
+	// A diagonal ray moves the same distance along its direction.
+	diagonal := NewRay(NewVec3(0, 0, 0), NewVec3(1, 1, 1))
+	for _, distance := range []float64{-3, 0.5, 2} {
+		if got := diagonal.At(distance).Mag(); !approxEqual(got, math.Abs(distance), 1e-12) {
+			t.Errorf("%v: expected the point at the distance %v from the origin, got %v", distance, math.Abs(distance), got)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"encoding/json"
+	"fmt"
 	"math"
 )
 
@@ -14,6 +16,32 @@ func NewVec3(x, y, z float64) *Vec3 {
 	return &Vec3{x, y, z}
 }
 
+// String formats the vector as "(x, y, z)".
+func (v *Vec3) String() string {
+	return fmt.Sprintf("(%.3f, %.3f, %.3f)", v.X, v.Y, v.Z)
+}
+
+// MarshalJSON encodes the vector as a [x, y, z] array.
+//
+// It has a value receiver so that it also applies to non-pointer vectors.
+func (v Vec3) MarshalJSON() ([]byte, error) {
+	return json.Marshal([3]float64{v.X, v.Y, v.Z})
+}
+
+// UnmarshalJSON decodes the vector from a [x, y, z] array.
+func (v *Vec3) UnmarshalJSON(data []byte) error {
+	var components []float64
+	if err := json.Unmarshal(data, &components); err != nil {
+		return fmt.Errorf("failed to decode vector: %w", err)
+	}
+	if len(components) != 3 {
+		return fmt.Errorf("vector must have 3 components, found %d", len(components))
+	}
+
+	v.X, v.Y, v.Z = components[0], components[1], components[2]
+	return nil
+}
+
 // Add adds the given vector to this vector
 // and returns the result.
 func (v *Vec3) Add(arg *Vec3) *Vec3 {
@@ -59,16 +87,67 @@ func (v *Vec3) Cross(arg *Vec3) *Vec3 {
 	)
 }
 
+// AddInto adds the given vector to this vector and stores the result in dst.
+// Unlike Add, it does not allocate. It returns dst for chaining.
+func (v *Vec3) AddInto(arg, dst *Vec3) *Vec3 {
+	dst.X, dst.Y, dst.Z = v.X+arg.X, v.Y+arg.Y, v.Z+arg.Z
+	return dst
+}
+
+// SubInto subtracts the given vector from this vector and stores the result in dst.
+// Unlike Sub, it does not allocate. It returns dst for chaining.
+func (v *Vec3) SubInto(arg, dst *Vec3) *Vec3 {
+	dst.X, dst.Y, dst.Z = v.X-arg.X, v.Y-arg.Y, v.Z-arg.Z
+	return dst
+}
+
+// MulInto multiplies the vector with the given argument and stores the result in dst.
+// Unlike Mul, it does not allocate. It returns dst for chaining.
+func (v *Vec3) MulInto(arg float64, dst *Vec3) *Vec3 {
+	dst.X, dst.Y, dst.Z = v.X*arg, v.Y*arg, v.Z*arg
+	return dst
+}
+
+// CrossInto calculates the cross product of this vector with the given vector
+// and stores the result in dst.
+// Unlike Cross, it does not allocate. It returns dst for chaining.
+//
+// The dst vector may be the same as either operand.
+func (v *Vec3) CrossInto(arg, dst *Vec3) *Vec3 {
+	dst.X, dst.Y, dst.Z = v.Y*arg.Z-v.Z*arg.Y, v.Z*arg.X-v.X*arg.Z, v.X*arg.Y-v.Y*arg.X
+	return dst
+}
+
 // Mag calculates the magnitude of the vector.
 func (v *Vec3) Mag() float64 {
 	return math.Sqrt(v.DotSelf())
 }
 
+// DistanceSquared returns the squared distance between this vector and the given vector.
+// It is cheaper than the actual distance as it avoids a square root.
+func (v *Vec3) DistanceSquared(arg *Vec3) float64 {
+	dx, dy, dz := v.X-arg.X, v.Y-arg.Y, v.Z-arg.Z
+	return dx*dx + dy*dy + dz*dz
+}
+
 // Dir calculates the direction (or unit vector) of this vector.
 func (v *Vec3) Dir() *Vec3 {
 	return v.Div(v.Mag())
 }
 
+// OrthonormalBasis returns two unit vectors that are perpendicular to each other
+// and to this vector. This vector is expected to be a unit vector.
+func (v *Vec3) OrthonormalBasis() (*Vec3, *Vec3) {
+	// Choose a helper axis that is not nearly parallel to this vector.
+	helper := NewVec3(1, 0, 0)
+	if math.Abs(v.X) > 0.9 {
+		helper = NewVec3(0, 1, 0)
+	}
+
+	first := v.Cross(helper).Dir()
+	return first, v.Cross(first)
+}
+
 // ToColour converts this vector to a Colour type by mapping
 // the x, y, z values to r, g, b values respectively.
 func (v *Vec3) ToColour() *Colour {
@@ -113,6 +192,24 @@ func (v *Vec3) Refracted(normal *Vec3, rir float64) *Vec3 {
 	return perpendicular.Add(parallel)
 }
 
+// RefractedChecked is like Refracted, except that it also reports whether refraction is possible.
+// It returns false on total internal reflection, in which case the returned vector is meaningless.
+//
+// To understand total internal reflection, visit-
+// https://raytracing.github.io/books/RayTracingInOneWeekend.html#dielectrics/totalinternalreflection
+func (v *Vec3) RefractedChecked(normal *Vec3, rir float64) (*Vec3, bool) {
+	// Safely calculating cosine and sine of the angle of incidence.
+	cosine := math.Min(v.Dir().Mul(-1).Dot(normal), 1)
+	sine := math.Sqrt(1 - cosine*cosine)
+
+	// Refraction is impossible when the sine of the angle of refraction goes above 1.
+	if rir*sine > 1 {
+		return nil, false
+	}
+
+	return v.Refracted(normal, rir), true
+}
+
 // Lerp stands for Linear Interpolation.
 //
 // The formula for linear interpolation is given by:
@@ -126,3 +223,15 @@ func (v *Vec3) IsNearZero() bool {
 	precision := 0.00001
 	return v.X < precision && v.Y < precision && v.Z < precision
 }
+
+// ApproxEqual returns true if all components of the vector differ from the given vector's by at most eps.
+// A NaN component is never equal to anything.
+func (v *Vec3) ApproxEqual(arg *Vec3, eps float64) bool {
+	return approxEqual(v.X, arg.X, eps) && approxEqual(v.Y, arg.Y, eps) && approxEqual(v.Z, arg.Z, eps)
+}
+
+// approxEqual returns true if the given values differ by at most eps.
+// It is false if either of them is NaN, since NaN compares false with everything.
+func approxEqual(a, b, eps float64) bool {
+	return math.Abs(a-b) <= eps
+}
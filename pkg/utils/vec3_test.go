@@ -0,0 +1,177 @@
+package utils
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestVec3_ApproxEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *Vec3
+		eps  float64
+		want bool
+	}{
+		{name: "identical", a: NewVec3(1, 2, 3), b: NewVec3(1, 2, 3), eps: 0, want: true},
+		{name: "within eps", a: NewVec3(1, 2, 3), b: NewVec3(1.25, 2, 3), eps: 0.5, want: true},
+		// The differences are exactly representable, so the comparison is exact.
+		{name: "exactly eps", a: NewVec3(1, 2, 3), b: NewVec3(1.5, 1.5, 3.5), eps: 0.5, want: true},
+		{name: "just beyond eps", a: NewVec3(1, 2, 3), b: NewVec3(math.Nextafter(1.5, 2), 2, 3), eps: 0.5, want: false},
+		{name: "beyond eps in one component", a: NewVec3(1, 2, 3), b: NewVec3(1, 2, 4), eps: 0.5, want: false},
+		{name: "NaN in first", a: NewVec3(math.NaN(), 2, 3), b: NewVec3(1, 2, 3), eps: 1, want: false},
+		{name: "NaN in second", a: NewVec3(1, 2, 3), b: NewVec3(1, 2, math.NaN()), eps: 1, want: false},
+		{name: "NaN in both", a: NewVec3(math.NaN(), 2, 3), b: NewVec3(math.NaN(), 2, 3), eps: 1, want: false},
+		{name: "NaN eps", a: NewVec3(1, 2, 3), b: NewVec3(1, 2, 3), eps: math.NaN(), want: false},
+	}
+
+	for _, test := range tests {
+		if got := test.a.ApproxEqual(test.b, test.eps); got != test.want {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestVec3_Into(t *testing.T) {
+	a, b := NewVec3(1.5, -2, 3.25), NewVec3(-0.5, 4, 2)
+
+	tests := []struct {
+		name string
+		want *Vec3
+		into func(dst *Vec3) *Vec3
+	}{
+		{name: "add", want: a.Add(b), into: func(dst *Vec3) *Vec3 { return a.AddInto(b, dst) }},
+		{name: "sub", want: a.Sub(b), into: func(dst *Vec3) *Vec3 { return a.SubInto(b, dst) }},
+		{name: "mul", want: a.Mul(-1.75), into: func(dst *Vec3) *Vec3 { return a.MulInto(-1.75, dst) }},
+		{name: "cross", want: a.Cross(b), into: func(dst *Vec3) *Vec3 { return a.CrossInto(b, dst) }},
+	}
+
+	for _, test := range tests {
+		dst := &Vec3{}
+		if got := test.into(dst); got != dst {
+			t.Errorf("%s: expected dst to be returned", test.name)
+		}
+		if *dst != *test.want {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, dst)
+		}
+		if allocs := testing.AllocsPerRun(10, func() { test.into(dst) }); allocs != 0 {
+			t.Errorf("%s: expected no allocations, got %v", test.name, allocs)
+		}
+	}
+
+	// The cross product may be stored into one of its own operands.
+	want, aliased := a.Cross(b), *a
+	aliased.CrossInto(b, &aliased)
+	if aliased != *want {
+		t.Errorf("aliased cross: expected %v, got %v", want, aliased)
+	}
+}
+
+func TestVec3_RefractedChecked(t *testing.T) {
+	normal := NewVec3(0, 1, 0)
+	// The critical angle of a glass-air interface (n = 1.5), which is about 41.8 degrees.
+	critical := math.Asin(1 / 1.5)
+
+	tests := []struct {
+		name       string
+		angle, rir float64
+		want       bool
+	}{
+		{name: "normal incidence into glass", angle: 0, rir: 1 / 1.5, want: true},
+		{name: "grazing incidence into glass", angle: 1.5, rir: 1 / 1.5, want: true},
+		{name: "normal incidence out of glass", angle: 0, rir: 1.5, want: true},
+		{name: "just below the critical angle", angle: critical - 1e-6, rir: 1.5, want: true},
+		{name: "just beyond the critical angle", angle: critical + 1e-6, rir: 1.5, want: false},
+		{name: "grazing incidence out of glass", angle: 1.5, rir: 1.5, want: false},
+	}
+
+	for _, test := range tests {
+		v := NewVec3(math.Sin(test.angle), -math.Cos(test.angle), 0)
+		refracted, got := v.RefractedChecked(normal, test.rir)
+		if got != test.want {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+			continue
+		}
+		if !got {
+			continue
+		}
+
+		// The refracted vector obeys Snell's law, and keeps going through the surface.
+		if sine := refracted.Dir().X; math.Abs(sine-test.rir*math.Sin(test.angle)) > 1e-9 || refracted.Y >= 0 {
+			t.Errorf("%s: expected a refraction with the sine %v, got %v", test.name,
+				test.rir*math.Sin(test.angle), refracted.Dir())
+		}
+	}
+}
+
+func TestVec3_DistanceSquared(t *testing.T) {
+	vectors := []*Vec3{
+		NewVec3(0, 0, 0), NewVec3(1, 2, 3), NewVec3(-1.5, 0.25, 4), NewVec3(1e6, -1e-6, 7), NewVec3(1, 2, 3),
+	}
+
+	for _, a := range vectors {
+		for _, b := range vectors {
+			want := a.Sub(b).DotSelf()
+			if got := a.DistanceSquared(b); math.Abs(got-want) > 1e-12*math.Max(want, 1) {
+				t.Errorf("%v to %v: expected %v, got %v", a, b, want, got)
+			}
+			if got := b.DistanceSquared(a); math.Abs(got-want) > 1e-12*math.Max(want, 1) {
+				t.Errorf("%v to %v: expected the distance to be symmetric, got %v and %v", b, a, got, want)
+			}
+		}
+	}
+}
+
+func TestVec3_String(t *testing.T) {
+	tests := []struct {
+		v    *Vec3
+		want string
+	}{
+		{v: NewVec3(0, 0, 0), want: "(0.000, 0.000, 0.000)"},
+		{v: NewVec3(1, -2.5, 0.125), want: "(1.000, -2.500, 0.125)"},
+		// Three decimals are kept.
+		{v: NewVec3(1.23456, -0.0004, 1000), want: "(1.235, -0.000, 1000.000)"},
+	}
+
+	for _, test := range tests {
+		if got := test.v.String(); got != test.want {
+			t.Errorf("expected %q, got %q", test.want, got)
+		}
+	}
+}
+
+func TestVec3_JSON(t *testing.T) {
+	for _, v := range []Vec3{{0, 0, 0}, {1, -2.5, 0.125}, {1e-9, 3e8, -math.Pi}} {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", v, err)
+		}
+		// Pointers are encoded the same way.
+		if pointerData, _ := json.Marshal(&v); string(pointerData) != string(data) {
+			t.Errorf("%v: expected the pointer to encode as %s, got %s", v, data, pointerData)
+		}
+
+		var decoded Vec3
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("%v: unexpected error: %v", v, err)
+		}
+		if decoded != v {
+			t.Errorf("expected %v to survive the round trip through %s, got %v", v, data, decoded)
+		}
+	}
+
+	if data, _ := json.Marshal(NewVec3(1, 2, 3)); string(data) != "[1,2,3]" {
+		t.Errorf("expected the vector to encode as an array, got %s", data)
+	}
+
+	for _, data := range []string{`[1, 2]`, `[1, 2, 3, 4]`, `{"X": 1}`} {
+		if err := json.Unmarshal([]byte(data), &Vec3{}); err == nil {
+			t.Errorf("%s: expected an error", data)
+		}
+	}
+
+	want := "vector must have 3 components, found 2"
+	if err := json.Unmarshal([]byte(`[1, 2]`), &Vec3{}); err == nil || err.Error() != want {
+		t.Errorf("expected the error %q, got %v", want, err)
+	}
+}